@@ -0,0 +1,30 @@
+package backend
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+)
+
+//Archive is the simulated backend of a contract, which also calls contracts at past blocks like an archive node.
+//The simulated backend itself only calls at the current block.
+type Archive struct {
+	*backends.SimulatedBackend
+	contract *Contract
+}
+
+//Archive returns the simulated backend of the contract able to call at past blocks.
+func (p *Contract) Archive() *Archive {
+	return &Archive{SimulatedBackend: p.Backend, contract: p}
+}
+
+//CallContract executes a call at the block, the current block if it is nil.
+func (p *Archive) CallContract(ctx context.Context, call ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	if block == nil {
+		return p.SimulatedBackend.CallContract(ctx, call, nil)
+	}
+	out, _, err := p.contract.TraceCall(call, block, stepsLogConfig)
+	return out, err
+}
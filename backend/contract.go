@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/compiler"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -28,8 +29,30 @@ type Contract struct {
 	ConstructorInputs []interface{}
 	Abi               *abi.ABI
 	Code              []byte
+	RuntimeCode       []byte
 	Address           common.Address
 	BlockDeployed     *big.Int
+
+	//Tracing turns on tracing of Execute. If it is not nil, every transaction executed
+	//is replayed with the given config and its trace is kept in LastTrace,
+	//and a failed transaction returns *RevertError with its receipt.
+	Tracing   *vm.LogConfig
+	LastTrace *Trace
+
+	//GasReport records gas used by Deploy and Execute, DefaultGasReport by default.
+	GasReport *GasReport
+	//Coverage records lines and branches executed by Execute, Call and LowCall, DefaultCoverage by default.
+	Coverage *Coverage
+	//GasProfile records gas used by solidity functions in Execute, DefaultGasProfile by default.
+	GasProfile *GasProfile
+
+	//Invariants are checked after every transaction of Execute, which returns *InvariantViolation
+	//for a transaction violating one of them. Violation keeps the first one.
+	Invariants []Invariant
+	Violation  *InvariantViolation
+
+	storageLayout *StorageLayout //loaded on demand by StorageLayout
+	sourceMap     *SourceMap     //loaded on demand by SourceMap
 }
 
 //NewContract is to create simulated backend and compile solidity code
@@ -45,8 +68,11 @@ func NewContract(file, name string) (*Contract, error) {
 			nil,
 			10000000,
 		),
-		OwnerKey: ownerKey,
-		Owner:    crypto.PubkeyToAddress(ownerKey.PublicKey),
+		OwnerKey:   ownerKey,
+		Owner:      crypto.PubkeyToAddress(ownerKey.PublicKey),
+		GasReport:  DefaultGasReport,
+		Coverage:   DefaultCoverage,
+		GasProfile: DefaultGasProfile,
 	}
 	//compile
 	if err := r.compile(); err != nil {
@@ -56,6 +82,28 @@ func NewContract(file, name string) (*Contract, error) {
 	return r, nil
 }
 
+//Clone returns the compiled contract on a new simulated backend with a new owner key, to be deployed again
+//without compiling. Settings of reports are shared, and the deployment is not.
+func (p *Contract) Clone() *Contract {
+	ownerKey, _ := crypto.GenerateKey()
+
+	return &Contract{
+		File:        p.File,
+		Name:        p.Name,
+		Backend:     backends.NewSimulatedBackend(nil, 10000000),
+		OwnerKey:    ownerKey,
+		Owner:       crypto.PubkeyToAddress(ownerKey.PublicKey),
+		Info:        p.Info,
+		Abi:         p.Abi,
+		Code:        p.Code,
+		RuntimeCode: p.RuntimeCode,
+		Tracing:     p.Tracing,
+		GasReport:   p.GasReport,
+		Coverage:    p.Coverage,
+		GasProfile:  p.GasProfile,
+	}
+}
+
 func (p *Contract) compile() error {
 	contracts, err := compiler.CompileSolidity("", p.File)
 	if err != nil {
@@ -65,7 +113,7 @@ func (p *Contract) compile() error {
 	//Get the contract to test from the compiled contracts.
 	contract, ok := contracts[fmt.Sprintf("%s:%s", p.File, p.Name)]
 	if ok == false {
-		fmt.Errorf("%s contract is not here", p.Name)
+		return fmt.Errorf("%s contract is not here", p.Name)
 	}
 	//make abi.ABI instance
 	abiBytes, err := json.Marshal(contract.Info.AbiDefinition)
@@ -79,6 +127,7 @@ func (p *Contract) compile() error {
 	p.Info = &contract.Info
 	p.Abi = &abi
 	p.Code = common.FromHex(contract.Code)
+	p.RuntimeCode = common.FromHex(contract.RuntimeCode)
 	return nil
 }
 
@@ -107,6 +156,9 @@ func (p *Contract) Deploy(args ...interface{}) error {
 	if err != nil {
 		return err
 	}
+	if p.GasReport != nil {
+		p.GasReport.Record(p.Name, "(deploy)", receipt.GasUsed, receipt.Status == 1)
+	}
 	if receipt.Status != 1 {
 		return fmt.Errorf("status of deploy tx receipt: %v", receipt.Status)
 	}
@@ -124,7 +176,7 @@ func (p *Contract) Call(result interface{}, method string, args ...interface{})
 		msg := ethereum.CallMsg{From: common.Address{}, To: &p.Address, Data: input}
 
 		out := result
-		if output, err := p.Backend.CallContract(context.TODO(), msg, nil); err != nil {
+		if output, err := p.callContract(msg); err != nil {
 			return err
 		} else if err := p.Abi.Unpack(out, method, output); err != nil {
 			return err
@@ -139,7 +191,7 @@ func (p *Contract) LowCall(method string, args ...interface{}) ([]interface{}, e
 		return nil, err
 	} else {
 		msg := ethereum.CallMsg{From: common.Address{}, To: &p.Address, Data: input}
-		if out, err := p.Backend.CallContract(context.TODO(), msg, nil); err != nil {
+		if out, err := p.callContract(msg); err != nil {
 			return []interface{}{}, err
 		} else {
 			if ret, err := p.Abi.Methods[method].Outputs.UnpackValues(out); err != nil {
@@ -153,6 +205,7 @@ func (p *Contract) LowCall(method string, args ...interface{}) ([]interface{}, e
 
 //Execute executes the contract's method. For that, take tx with signer's key, method and inputs,
 //and then send it to the simulated backend, and return the receipt.
+//With Tracing, a failed transaction also returns *RevertError located in the source.
 func (p *Contract) Execute(key *ecdsa.PrivateKey, method string, args ...interface{}) (*types.Receipt, error) {
 	if key == nil {
 		key = p.OwnerKey
@@ -182,6 +235,69 @@ func (p *Contract) Execute(key *ecdsa.PrivateKey, method string, args ...interfa
 	if err != nil {
 		return nil, err
 	}
+	if p.GasReport != nil {
+		p.GasReport.Record(p.Name, method, receipt.GasUsed, receipt.Status == 1)
+	}
 
+	trace := (*Trace)(nil)
+	if p.Tracing != nil || p.Coverage != nil || p.GasProfile != nil {
+		cfg := p.Tracing
+		if cfg == nil {
+			cfg = stepsLogConfig
+		}
+		if trace, err = p.TraceTransaction(tx.Hash(), cfg); err != nil {
+			return nil, err
+		}
+		if err := p.recordCoverage(trace); err != nil {
+			return nil, err
+		}
+		if p.GasProfile != nil {
+			m, err := p.SourceMap()
+			if err != nil {
+				return nil, err
+			}
+			p.GasProfile.Record(m, trace, p.Address, p.Name)
+		}
+		if p.Tracing != nil {
+			p.LastTrace = trace
+			if e := p.RevertError(trace); e != nil {
+				return receipt, e
+			}
+		}
+	}
+	if len(p.Invariants) > 0 {
+		if err := p.checkInvariants(method, tx.Hash(), receipt.BlockNumber, trace); err != nil {
+			return receipt, err
+		}
+	}
 	return receipt, nil
 }
+
+//stepsLogConfig captures steps without memory, stack and storage, which is enough for coverage and profiles.
+var stepsLogConfig = &vm.LogConfig{DisableMemory: true, DisableStack: true, DisableStorage: true}
+
+//callContract invokes a view method, and traces it when coverage is recorded.
+func (p *Contract) callContract(msg ethereum.CallMsg) ([]byte, error) {
+	if p.Coverage == nil {
+		return p.Backend.CallContract(context.TODO(), msg, nil)
+	}
+	out, trace, err := p.TraceCall(msg, nil, stepsLogConfig)
+	if trace != nil {
+		if err := p.recordCoverage(trace); err != nil {
+			return nil, err
+		}
+	}
+	return out, err
+}
+
+func (p *Contract) recordCoverage(trace *Trace) error {
+	if p.Coverage == nil {
+		return nil
+	}
+	m, err := p.SourceMap()
+	if err != nil {
+		return err
+	}
+	p.Coverage.Record(m, trace, p.Address)
+	return nil
+}
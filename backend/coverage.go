@@ -0,0 +1,340 @@
+package backend
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+//Coverage collects solidity lines and branches executed by traced transactions and calls.
+//Only instructions inside functions and modifiers are counted, so the dispatcher and
+//other code generated for the whole contract are left out.
+//A line is hit once per transaction or call executing any instruction on it,
+//and each JUMPI is a branch point with a taken and a not taken branch.
+type Coverage struct {
+	mu    sync.Mutex
+	files map[string]*fileCoverage
+}
+
+type fileCoverage struct {
+	sourceMap *SourceMap
+	lines     map[int]int        //line => hits
+	branches  map[uint64]*[2]int //pc of JUMPI => hits of not taken and taken
+	branchAt  map[uint64]int     //pc of JUMPI => line
+	functions map[string]int     //function => line
+	called    map[string]int     //function => hits
+}
+
+//DefaultCoverage is the coverage which contracts made by NewContract record to. It is nil by default.
+var DefaultCoverage *Coverage
+
+//NewCoverage returns an empty coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{files: make(map[string]*fileCoverage)}
+}
+
+//Record adds lines and branches executed by the code at the address in a trace.
+func (p *Coverage) Record(m *SourceMap, trace *Trace, address common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f := p.file(m)
+	lines := map[int]bool{}
+	functions := map[string]bool{}
+	for i := range trace.StructLogs {
+		step := &trace.StructLogs[i]
+		if step.Address != address {
+			continue
+		}
+		loc := m.Lookup(step.Pc)
+		if loc == nil || loc.Function == "" {
+			continue
+		}
+		lines[loc.Line] = true
+		functions[loc.Function] = true
+
+		if b, ok := f.branches[step.Pc]; ok && step.Op == vm.JUMPI.String() && i+1 < len(trace.StructLogs) {
+			next := &trace.StructLogs[i+1]
+			if next.Depth == step.Depth && next.Address == address {
+				if next.Pc == step.Pc+1 {
+					b[0]++
+				} else {
+					b[1]++
+				}
+			}
+		}
+	}
+	for line := range lines {
+		f.lines[line]++
+	}
+	for name := range functions {
+		f.called[name]++
+	}
+}
+
+//file returns coverage of the source of the map, and makes it with all executable lines on the first call.
+func (p *Coverage) file(m *SourceMap) *fileCoverage {
+	if f, ok := p.files[m.File]; ok {
+		return f
+	}
+	f := &fileCoverage{
+		sourceMap: m,
+		lines:     make(map[int]int),
+		branches:  make(map[uint64]*[2]int),
+		branchAt:  make(map[uint64]int),
+		functions: make(map[string]int),
+		called:    make(map[string]int),
+	}
+	for _, pc := range m.Pcs() {
+		loc := m.Lookup(pc)
+		if loc == nil || loc.Function == "" {
+			continue
+		}
+		f.lines[loc.Line] = 0
+		if m.Op(pc) == vm.JUMPI {
+			f.branches[pc] = &[2]int{}
+			f.branchAt[pc] = loc.Line
+		}
+	}
+	for _, fn := range m.functions {
+		line, _ := m.Position(fn.start)
+		if old, ok := f.functions[fn.name]; ok == false || line < old {
+			f.functions[fn.name] = line
+		}
+	}
+	p.files[m.File] = f
+	return f
+}
+
+//CoverageSummary is the number of lines and branches found and hit in a file.
+type CoverageSummary struct {
+	File                         string
+	LinesFound, LinesHit         int
+	BranchesFound, BranchesHit   int
+	FunctionsFound, FunctionsHit int
+}
+
+//LinePercent returns the percentage of lines hit.
+func (s CoverageSummary) LinePercent() float64 {
+	return percent(s.LinesHit, s.LinesFound)
+}
+
+//BranchPercent returns the percentage of branches hit.
+func (s CoverageSummary) BranchPercent() float64 {
+	return percent(s.BranchesHit, s.BranchesFound)
+}
+
+func percent(hit, found int) float64 {
+	if found == 0 {
+		return 100
+	}
+	return float64(hit) * 100 / float64(found)
+}
+
+//Summary returns the summary of each file sorted by file name.
+func (p *Coverage) Summary() []CoverageSummary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := []CoverageSummary{}
+	for _, name := range p.fileNames() {
+		ret = append(ret, p.files[name].summary(name))
+	}
+	return ret
+}
+
+func (f *fileCoverage) summary(name string) CoverageSummary {
+	s := CoverageSummary{File: name}
+	for _, hits := range f.lines {
+		s.LinesFound++
+		if hits > 0 {
+			s.LinesHit++
+		}
+	}
+	for _, b := range f.branches {
+		s.BranchesFound += 2
+		if b[0] > 0 {
+			s.BranchesHit++
+		}
+		if b[1] > 0 {
+			s.BranchesHit++
+		}
+	}
+	for name := range f.functions {
+		s.FunctionsFound++
+		if f.called[name] > 0 {
+			s.FunctionsHit++
+		}
+	}
+	return s
+}
+
+func (p *Coverage) fileNames() []string {
+	ret := make([]string, 0, len(p.files))
+	for name := range p.files {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func sortedLines(m map[int]int) []int {
+	ret := make([]int, 0, len(m))
+	for line := range m {
+		ret = append(ret, line)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+//sortedBranches returns pcs of branch points ordered by line and pc.
+func (f *fileCoverage) sortedBranches() []uint64 {
+	ret := make([]uint64, 0, len(f.branches))
+	for pc := range f.branches {
+		ret = append(ret, pc)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if f.branchAt[ret[i]] != f.branchAt[ret[j]] {
+			return f.branchAt[ret[i]] < f.branchAt[ret[j]]
+		}
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+//WriteLCOV writes the coverage in the lcov tracefile format.
+func (p *Coverage) WriteLCOV(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, name := range p.fileNames() {
+		f := p.files[name]
+		s := f.summary(name)
+		fmt.Fprintf(w, "TN:\nSF:%s\n", name)
+
+		functions := make([]string, 0, len(f.functions))
+		for fn := range f.functions {
+			functions = append(functions, fn)
+		}
+		sort.Slice(functions, func(i, j int) bool { return f.functions[functions[i]] < f.functions[functions[j]] })
+		for _, fn := range functions {
+			fmt.Fprintf(w, "FN:%d,%s\n", f.functions[fn], fn)
+		}
+		for _, fn := range functions {
+			fmt.Fprintf(w, "FNDA:%d,%s\n", f.called[fn], fn)
+		}
+		fmt.Fprintf(w, "FNF:%d\nFNH:%d\n", s.FunctionsFound, s.FunctionsHit)
+
+		for i, pc := range f.sortedBranches() {
+			b := f.branches[pc]
+			for n := 0; n < 2; n++ {
+				taken := "-"
+				if b[0]+b[1] > 0 {
+					taken = fmt.Sprint(b[n])
+				}
+				fmt.Fprintf(w, "BRDA:%d,%d,%d,%s\n", f.branchAt[pc], i, n, taken)
+			}
+		}
+		fmt.Fprintf(w, "BRF:%d\nBRH:%d\n", s.BranchesFound, s.BranchesHit)
+
+		for _, line := range sortedLines(f.lines) {
+			fmt.Fprintf(w, "DA:%d,%d\n", line, f.lines[line])
+		}
+		if _, err := fmt.Fprintf(w, "LF:%d\nLH:%d\nend_of_record\n", s.LinesFound, s.LinesHit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var coverageHTML = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Solidity coverage</title>
+<style>
+body { font-family: sans-serif; }
+table.source { border-collapse: collapse; font-family: monospace; white-space: pre; }
+table.source td { padding: 0 8px; }
+.hit { background: #dfd; }
+.miss { background: #fdd; }
+.partial { background: #ffd; }
+.num { color: #888; text-align: right; }
+</style>
+</head>
+<body>
+{{range .}}
+<h2>{{.Summary.File}}</h2>
+<p>lines {{.Summary.LinesHit}}/{{.Summary.LinesFound}} ({{printf "%.1f" .Summary.LinePercent}}%),
+branches {{.Summary.BranchesHit}}/{{.Summary.BranchesFound}} ({{printf "%.1f" .Summary.BranchPercent}}%),
+functions {{.Summary.FunctionsHit}}/{{.Summary.FunctionsFound}}</p>
+<table class="source">
+{{range .Lines}}<tr class="{{.Class}}"><td class="num">{{.Number}}</td><td class="num">{{if .Executable}}{{.Hits}}{{end}}</td><td class="num">{{.Branches}}</td><td>{{.Text}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+//WriteHTML writes the coverage as a html page showing the source annotated with hits.
+func (p *Coverage) WriteHTML(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	type htmlLine struct {
+		Number     int
+		Text       string
+		Executable bool
+		Hits       int
+		Branches   string
+		Class      string
+	}
+	type htmlFile struct {
+		Summary CoverageSummary
+		Lines   []htmlLine
+	}
+
+	files := []htmlFile{}
+	for _, name := range p.fileNames() {
+		f := p.files[name]
+
+		//branches hit and found per line
+		hit, found := map[int]int{}, map[int]int{}
+		for pc, b := range f.branches {
+			line := f.branchAt[pc]
+			found[line] += 2
+			if b[0] > 0 {
+				hit[line]++
+			}
+			if b[1] > 0 {
+				hit[line]++
+			}
+		}
+
+		h := htmlFile{Summary: f.summary(name)}
+		for n := 1; n <= len(f.sourceMap.lineStart); n++ {
+			l := htmlLine{Number: n, Text: f.sourceMap.Line(n)}
+			l.Hits, l.Executable = f.lines[n]
+			if found[n] > 0 {
+				l.Branches = fmt.Sprintf("%d/%d", hit[n], found[n])
+			}
+			switch {
+			case l.Executable == false:
+			case l.Hits == 0:
+				l.Class = "miss"
+			case hit[n] < found[n]:
+				l.Class = "partial"
+			default:
+				l.Class = "hit"
+			}
+			h.Lines = append(h.Lines, l)
+		}
+		files = append(files, h)
+	}
+	return coverageHTML.Execute(w, files)
+}
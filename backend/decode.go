@@ -0,0 +1,164 @@
+package backend
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+//DecodedArg is an argument of decoded calldata.
+type DecodedArg struct {
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Value  interface{} `json:"value"`
+	Amount string      `json:"amount,omitempty"` //in token units if it is an amount, set by FormatAmounts
+}
+
+//DecodedCall is calldata decoded with an ABI.
+//A selector which is not in the ABI is kept with Unknown set, instead of an error.
+type DecodedCall struct {
+	Selector string       `json:"selector"`
+	Method   string       `json:"method,omitempty"`
+	Unknown  bool         `json:"unknown,omitempty"`
+	Args     []DecodedArg `json:"args,omitempty"`
+	Data     string       `json:"data,omitempty"` //calldata of an unknown selector
+}
+
+//DecodedTransaction is a raw signed transaction with its calldata decoded.
+type DecodedTransaction struct {
+	Hash     common.Hash     `json:"hash"`
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Nonce    uint64          `json:"nonce"`
+	Gas      uint64          `json:"gas"`
+	GasPrice *big.Int        `json:"gasPrice"`
+	Value    *big.Int        `json:"value"`
+	ChainID  *big.Int        `json:"chainId,omitempty"` //nil for a transaction without replay protection
+	Call     *DecodedCall    `json:"call,omitempty"`    //nil for a transaction without calldata
+}
+
+//DecodeCalldata decodes calldata with the contract's ABI.
+func (p *Contract) DecodeCalldata(data []byte) (*DecodedCall, error) {
+	return DecodeCalldata(p.Abi, data)
+}
+
+//DecodeTransaction decodes a raw signed transaction with the contract's ABI.
+func (p *Contract) DecodeTransaction(raw []byte) (*DecodedTransaction, error) {
+	return DecodeTransaction(p.Abi, raw)
+}
+
+//DecodeCalldata decodes calldata into the method and its named arguments.
+func DecodeCalldata(a *abi.ABI, data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata is %d bytes, shorter than a selector", len(data))
+	}
+	r := &DecodedCall{Selector: hexutil.Encode(data[:4])}
+	method, err := a.MethodById(data[:4])
+	if err != nil {
+		r.Unknown = true
+		r.Data = hexutil.Encode(data)
+		return r, nil
+	}
+	r.Method = method.Name
+
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", method.Sig, err)
+	}
+	//UnpackValues ignores extra bytes, so the length is checked by packing back.
+	if packed, err := method.Inputs.Pack(values...); err == nil && len(packed) != len(data)-4 {
+		return nil, fmt.Errorf("%s: %d bytes of arguments, expected %d", method.Sig, len(data)-4, len(packed))
+	}
+	for i, input := range method.Inputs {
+		r.Args = append(r.Args, DecodedArg{Name: input.Name, Type: input.Type.String(), Value: values[i]})
+	}
+	return r, nil
+}
+
+//DecodeTransaction decodes a RLP encoded signed transaction and its calldata.
+func DecodeTransaction(a *abi.ABI, raw []byte) (*DecodedTransaction, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, tx); err != nil {
+		return nil, err
+	}
+	//an unprotected transaction falls back to the homestead signer.
+	from, err := types.Sender(types.NewEIP155Signer(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+	r := &DecodedTransaction{
+		Hash:     tx.Hash(),
+		From:     from,
+		To:       tx.To(),
+		Nonce:    tx.Nonce(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice(),
+		Value:    tx.Value(),
+	}
+	if tx.Protected() {
+		r.ChainID = tx.ChainId()
+	}
+	if len(tx.Data()) > 0 {
+		if r.Call, err = DecodeCalldata(a, tx.Data()); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+//FormatAmounts sets Amount of the integer arguments whose names are in names, formatted by format.
+func (c *DecodedCall) FormatAmounts(names map[string]bool, format func(amount *big.Int) string) {
+	for i, arg := range c.Args {
+		if v, ok := arg.Value.(*big.Int); ok && names[arg.Name] {
+			c.Args[i].Amount = format(v)
+		}
+	}
+}
+
+func (c *DecodedCall) String() string {
+	if c.Unknown {
+		return fmt.Sprintf("UNKNOWN SELECTOR %s, not a method of the ABI\n  data: %s", c.Selector, c.Data)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)", c.Method, c.Selector)
+	for _, arg := range c.Args {
+		fmt.Fprintf(&b, "\n  %s %s: %s", arg.Type, arg.Name, formatValue(arg.Value))
+		if arg.Amount != "" {
+			fmt.Fprintf(&b, " (%s)", arg.Amount)
+		}
+	}
+	return b.String()
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+	return fmt.Sprint(v)
+}
+
+func (t *DecodedTransaction) String() string {
+	to := "(contract creation)"
+	if t.To != nil {
+		to = t.To.Hex()
+	}
+	chainID := "none"
+	if t.ChainID != nil {
+		chainID = t.ChainID.String()
+	}
+	s := fmt.Sprintf("tx %s\n  from: %s\n  to: %s\n  nonce: %d, gas: %d, gasPrice: %v, value: %v, chainId: %s",
+		t.Hash.Hex(), t.From.Hex(), to, t.Nonce, t.Gas, t.GasPrice, t.Value, chainID)
+	if t.Call != nil {
+		s += "\n" + t.Call.String()
+	}
+	return s
+}
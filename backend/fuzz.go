@@ -0,0 +1,285 @@
+package backend
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//Fuzzer calls non-view methods of a deployed contract with random senders and random arguments by their ABI types.
+//Calls are checked against Rules before they are sent, and against Invariants of the contract after them.
+type Fuzzer struct {
+	Contract  *Contract
+	Senders   []*ecdsa.PrivateKey //keys sending calls
+	Addresses []common.Address    //known accounts given as address arguments, besides the zero address and the contract
+	Values    []*big.Int          //integers given as arguments besides edge cases of their types, e.g. amounts used by the contract
+	Methods   []string            //methods called, all non-view methods of the ABI by default
+	Rules     []FuzzRule
+}
+
+//FuzzRule is an expectation on a call of the fuzzer.
+type FuzzRule struct {
+	Name string
+	//MustFail returns whether the call has to fail, on the state before the call.
+	MustFail func(c *Contract, call *FuzzCall) (bool, error)
+}
+
+//FuzzCall is a call made by the fuzzer.
+type FuzzCall struct {
+	Sender common.Address
+	Method string
+	Args   []interface{}
+}
+
+func (c *FuzzCall) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = formatValue(arg)
+	}
+	return fmt.Sprintf("%s: %s(%s)", c.Sender.Hex(), c.Method, strings.Join(args, ", "))
+}
+
+//FuzzFailure is a call which broke a rule or an invariant.
+type FuzzFailure struct {
+	Seed  int64
+	Calls []*FuzzCall //calls from the first one, the last one failed
+	Rule  string      //rule broken, empty for an invariant
+	Err   error       //*InvariantViolation for an invariant
+}
+
+func (f *FuzzFailure) Error() string {
+	var b strings.Builder
+	if f.Rule != "" {
+		fmt.Fprintf(&b, "seed %d, call %d: unexpected success, %s", f.Seed, len(f.Calls), f.Rule)
+	} else {
+		fmt.Fprintf(&b, "seed %d, call %d: %v", f.Seed, len(f.Calls), f.Err)
+	}
+	for i, call := range f.Calls {
+		fmt.Fprintf(&b, "\n  %d %s", i+1, call)
+	}
+	return b.String()
+}
+
+//NewFuzzer returns a fuzzer of the deployed contract, sending calls by the owner and the keys.
+func NewFuzzer(contract *Contract, keys ...*ecdsa.PrivateKey) *Fuzzer {
+	r := &Fuzzer{
+		Contract: contract,
+		Senders:  append([]*ecdsa.PrivateKey{contract.OwnerKey}, keys...),
+	}
+	for _, key := range r.Senders {
+		r.Addresses = append(r.Addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return r
+}
+
+//OnlyOwner returns a rule that calls of the methods sent by another account than owner() have to fail.
+func OnlyOwner(methods ...string) FuzzRule {
+	only := map[string]bool{}
+	for _, method := range methods {
+		only[method] = true
+	}
+	return FuzzRule{
+		Name: "only the owner can call " + strings.Join(methods, ", "),
+		MustFail: func(c *Contract, call *FuzzCall) (bool, error) {
+			if only[call.Method] == false {
+				return false, nil
+			}
+			ret, err := c.view("owner")
+			if err != nil {
+				return false, err
+			}
+			return ret[0].(common.Address) != call.Sender, nil
+		},
+	}
+}
+
+//view calls a view method without recording coverage.
+func (p *Contract) view(method string, args ...interface{}) ([]interface{}, error) {
+	input, err := p.Abi.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := p.Backend.CallContract(context.Background(), ethereum.CallMsg{To: &p.Address, Data: input}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p.Abi.Methods[method].Outputs.UnpackValues(out)
+}
+
+//methods returns names of methods called, sorted to keep calls of a seed the same.
+func (f *Fuzzer) methods() []string {
+	if len(f.Methods) > 0 {
+		return f.Methods
+	}
+	ret := []string{}
+	for name, method := range f.Contract.Abi.Methods {
+		if method.IsConstant() == false {
+			ret = append(ret, name)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+//Run sends steps random calls from the seed, and returns the first call breaking a rule or an invariant.
+//Running the seed again on the same deployment replays the same calls.
+func (f *Fuzzer) Run(seed int64, steps int) (*FuzzFailure, error) {
+	rng := rand.New(rand.NewSource(seed))
+	methods := f.methods()
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("%s has no non-view methods to call", f.Contract.Name)
+	}
+	if len(f.Senders) == 0 {
+		return nil, errors.New("no senders to call with")
+	}
+	calls := []*FuzzCall{}
+	for i := 0; i < steps; i++ {
+		sender := rng.Intn(len(f.Senders))
+		call, err := f.NewCall(rng, methods[rng.Intn(len(methods))])
+		if err != nil {
+			return nil, err
+		}
+		call.Sender = crypto.PubkeyToAddress(f.Senders[sender].PublicKey)
+		calls = append(calls, call)
+
+		broken := ""
+		for _, rule := range f.Rules {
+			mustFail, err := rule.MustFail(f.Contract, call)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", rule.Name, err)
+			}
+			if mustFail && broken == "" {
+				broken = rule.Name
+			}
+		}
+
+		r, err := f.Contract.Execute(f.Senders[sender], call.Method, call.Args...)
+		violation := (*InvariantViolation)(nil)
+		if errors.As(err, &violation) {
+			return &FuzzFailure{Seed: seed, Calls: calls, Err: violation}, nil
+		}
+		if revert := (*RevertError)(nil); errors.As(err, &revert) {
+			err = nil //a failed call with Tracing
+		}
+		if err != nil {
+			return nil, err
+		}
+		if broken != "" && r.Status == 1 {
+			return &FuzzFailure{Seed: seed, Calls: calls, Rule: broken}, nil
+		}
+	}
+	return nil, nil
+}
+
+//NewCall returns a call of the method with random arguments, without the sender.
+func (f *Fuzzer) NewCall(rng *rand.Rand, method string) (*FuzzCall, error) {
+	m, ok := f.Contract.Abi.Methods[method]
+	if ok == false {
+		return nil, fmt.Errorf("%s is not a method of %s", method, f.Contract.Name)
+	}
+	call := &FuzzCall{Method: method}
+	for _, input := range m.Inputs {
+		v, err := f.randomValue(rng, input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %v", m.Sig, input.Name, err)
+		}
+		call.Args = append(call.Args, v)
+	}
+	return call, nil
+}
+
+//randomValue returns a value of the ABI type, mostly out of edge cases and known values.
+func (f *Fuzzer) randomValue(rng *rand.Rand, t abi.Type) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		addresses := append([]common.Address{{}, f.Contract.Address}, f.Addresses...)
+		if rng.Intn(8) == 0 {
+			return common.BytesToAddress(randomBytes(rng, common.AddressLength)), nil
+		}
+		return addresses[rng.Intn(len(addresses))], nil
+	case abi.UintTy, abi.IntTy:
+		word := math.U256Bytes(f.randomInteger(rng, t))
+		return abi.ReadInteger(t, word), nil
+	case abi.BoolTy:
+		return rng.Intn(2) == 0, nil
+	case abi.StringTy:
+		return []string{"", "a", strings.Repeat("x", 40)}[rng.Intn(3)], nil
+	case abi.BytesTy:
+		return randomBytes(rng, []int{0, 1, 32, 33}[rng.Intn(4)]), nil
+	case abi.FixedBytesTy:
+		v := reflect.New(reflect.ArrayOf(t.Size, reflect.TypeOf(byte(0)))).Elem()
+		reflect.Copy(v, reflect.ValueOf(randomBytes(rng, t.Size)))
+		return v.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		n := t.Size
+		if t.T == abi.SliceTy {
+			n = rng.Intn(4)
+		}
+		//an element is made even for an empty slice, to know the Go type of elements
+		elems := []reflect.Value{}
+		for i := 0; i < n || i == 0; i++ {
+			e, err := f.randomValue(rng, *t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, reflect.ValueOf(e))
+		}
+		v := reflect.MakeSlice(reflect.SliceOf(elems[0].Type()), n, n)
+		if t.T == abi.ArrayTy {
+			v = reflect.New(reflect.ArrayOf(n, elems[0].Type())).Elem()
+		}
+		for i := 0; i < n; i++ {
+			v.Index(i).Set(elems[i])
+		}
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("arguments of type %s are not supported", t)
+}
+
+//randomInteger returns an integer in the range of the type:
+//0, 1, the minimum and the maximum and their neighbours, powers of 2 and 10, known values and random ones.
+func (f *Fuzzer) randomInteger(rng *rand.Rand, t abi.Type) *big.Int {
+	hi := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, uint(t.Size)), common.Big1)
+	lo := new(big.Int)
+	if t.T == abi.IntTy {
+		hi.Rsh(hi, 1)
+		lo.Neg(hi).Sub(lo, common.Big1)
+	}
+	candidates := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(2),
+		hi, new(big.Int).Sub(hi, common.Big1),
+		lo, new(big.Int).Add(lo, common.Big1),
+		new(big.Int).Lsh(common.Big1, uint(rng.Intn(t.Size))),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(rng.Intn(30))), nil),
+		new(big.Int).Rand(rng, hi),
+	}
+	if t.T == abi.IntTy {
+		candidates = append(candidates, big.NewInt(-1))
+	}
+	for _, v := range f.Values {
+		candidates = append(candidates, v, new(big.Int).Add(v, common.Big1), new(big.Int).Sub(v, common.Big1))
+	}
+	v := candidates[rng.Intn(len(candidates))]
+	if v.Cmp(hi) > 0 || v.Cmp(lo) < 0 {
+		return new(big.Int).Set(hi)
+	}
+	return new(big.Int).Set(v)
+}
+
+func randomBytes(rng *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	rng.Read(b)
+	return b
+}
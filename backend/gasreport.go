@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+)
+
+//GasReport collects gas used by contract methods executed.
+type GasReport struct {
+	mu      sync.Mutex
+	methods map[string]*GasStats //"contract.method" => stats
+}
+
+//GasStats is gas used by a method of a contract.
+//Only successful transactions are counted in gas, failed ones are counted in Reverted.
+type GasStats struct {
+	Contract string
+	Method   string
+	Count    int
+	Reverted int
+	Min      uint64
+	Max      uint64
+	Total    uint64
+}
+
+//DefaultGasReport is the gas report which contracts made by NewContract record to.
+var DefaultGasReport = NewGasReport()
+
+//NewGasReport returns an empty gas report.
+func NewGasReport() *GasReport {
+	return &GasReport{methods: make(map[string]*GasStats)}
+}
+
+//Mean returns the average gas used.
+func (s *GasStats) Mean() uint64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / uint64(s.Count)
+}
+
+//Record adds gas used by a transaction executing the method.
+func (p *GasReport) Record(contract, method string, gas uint64, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := contract + "." + method
+	s, ok := p.methods[key]
+	if ok == false {
+		s = &GasStats{Contract: contract, Method: method}
+		p.methods[key] = s
+	}
+	if success == false {
+		s.Reverted++
+		return
+	}
+	if s.Count == 0 || gas < s.Min {
+		s.Min = gas
+	}
+	if gas > s.Max {
+		s.Max = gas
+	}
+	s.Count++
+	s.Total += gas
+}
+
+//Stats returns copies of the stats sorted by contract and method.
+func (p *GasReport) Stats() []GasStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := make([]GasStats, 0, len(p.methods))
+	for _, s := range p.methods {
+		ret = append(ret, *s)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Contract != ret[j].Contract {
+			return ret[i].Contract < ret[j].Contract
+		}
+		return ret[i].Method < ret[j].Method
+	})
+	return ret
+}
+
+//Print writes the report as a table. Nothing is written if no method was recorded.
+func (p *GasReport) Print(w io.Writer) error {
+	stats := p.Stats()
+	if len(stats) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "contract\tmethod\tmin\tmax\tmean\tcount\treverted\t")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t\n", s.Contract, s.Method, s.Min, s.Max, s.Mean(), s.Count, s.Reverted)
+	}
+	return tw.Flush()
+}
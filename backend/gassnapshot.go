@@ -0,0 +1,119 @@
+package backend
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//GasSnapshot is gas used by named scenarios.
+//It is stored as a text file with a "<name> (gas: <gas>)" line per scenario, sorted by name.
+//Lines starting with # are comments.
+type GasSnapshot map[string]uint64
+
+//GasDiff is a change of gas used by a scenario between two snapshots.
+//Old is 0 for a new scenario and New is 0 for a removed one.
+type GasDiff struct {
+	Name     string
+	Old, New uint64
+	Exceeded bool //the change is out of the tolerance
+}
+
+const gasSnapshotHeader = "# gas used by scenarios of test/gassnapshot_test.go\n# regenerate with: go test ./test -run TestGasSnapshot -update-gas-snapshot\n"
+
+//LoadGasSnapshot reads a snapshot file. A file which does not exist is an empty snapshot.
+func LoadGasSnapshot(file string) (GasSnapshot, error) {
+	ret := GasSnapshot{}
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return ret, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndex(line, " (gas: ")
+		if i < 0 || strings.HasSuffix(line, ")") == false {
+			return nil, fmt.Errorf("%s:%d: invalid line %q", file, n, line)
+		}
+		gas, err := strconv.ParseUint(line[i+len(" (gas: "):len(line)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", file, n, err)
+		}
+		ret[line[:i]] = gas
+	}
+	return ret, scanner.Err()
+}
+
+//Save writes the snapshot to a file.
+func (p GasSnapshot) Save(file string) error {
+	var b strings.Builder
+	b.WriteString(gasSnapshotHeader)
+	for _, name := range p.names() {
+		fmt.Fprintf(&b, "%s (gas: %d)\n", name, p[name])
+	}
+	return ioutil.WriteFile(file, []byte(b.String()), 0644)
+}
+
+func (p GasSnapshot) names() []string {
+	ret := make([]string, 0, len(p))
+	for name := range p {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+//Compare returns changes from the snapshot to the current gas, sorted by name.
+//A change of a scenario in both snapshots is exceeded when it moves by more than tolerance percent.
+//New and removed scenarios are listed, but never exceeded.
+func (p GasSnapshot) Compare(current GasSnapshot, tolerance float64) []GasDiff {
+	all := GasSnapshot{}
+	for name := range p {
+		all[name] = 0
+	}
+	for name := range current {
+		all[name] = 0
+	}
+
+	ret := []GasDiff{}
+	for _, name := range all.names() {
+		d := GasDiff{Name: name, Old: p[name], New: current[name]}
+		if d.Old == d.New {
+			continue
+		}
+		if d.Old != 0 && d.New != 0 {
+			d.Exceeded = d.Percent() > tolerance || d.Percent() < -tolerance
+		}
+		ret = append(ret, d)
+	}
+	return ret
+}
+
+//Percent returns the change in percent of the old gas.
+func (d GasDiff) Percent() float64 {
+	if d.Old == 0 {
+		return 100
+	}
+	return (float64(d.New) - float64(d.Old)) * 100 / float64(d.Old)
+}
+
+func (d GasDiff) String() string {
+	switch {
+	case d.Old == 0:
+		return fmt.Sprintf("%s: new (gas: %d)", d.Name, d.New)
+	case d.New == 0:
+		return fmt.Sprintf("%s: removed (gas: %d)", d.Name, d.Old)
+	}
+	return fmt.Sprintf("%s: %d -> %d (%+d, %+.2f%%)", d.Name, d.Old, d.New, int64(d.New)-int64(d.Old), d.Percent())
+}
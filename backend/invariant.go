@@ -0,0 +1,136 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//Invariant is a property of the contract checked on the latest block after every transaction of Execute.
+//Check may keep state between calls, e.g. to compare with the previous block.
+type Invariant struct {
+	Name  string
+	Check func(p *Contract) error
+}
+
+//StorageChange is a storage slot of the contract written by a transaction.
+type StorageChange struct {
+	Slot   common.Hash
+	Labels []string //state variables stored in the slot, empty for slots of mappings and arrays
+	Old    common.Hash
+	New    common.Hash
+}
+
+//InvariantViolation is returned by Execute for the transaction after which an invariant does not hold.
+type InvariantViolation struct {
+	Invariant string
+	Method    string
+	TxHash    common.Hash
+	Block     *big.Int
+	Err       error
+	Diff      []StorageChange //storage of the contract changed by the transaction
+}
+
+func (e *InvariantViolation) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invariant %q violated by %s in tx %s at block %v: %v", e.Invariant, e.Method, e.TxHash.Hex(), e.Block, e.Err)
+	for _, c := range e.Diff {
+		label := ""
+		if len(c.Labels) > 0 {
+			label = " (" + strings.Join(c.Labels, ", ") + ")"
+		}
+		fmt.Fprintf(&b, "\n  slot %s%s: %s -> %s", c.Slot.Hex(), label, c.Old.Big(), c.New.Big())
+	}
+	return b.String()
+}
+
+func (e *InvariantViolation) Unwrap() error {
+	return e.Err
+}
+
+//AddInvariant registers an invariant checked after every transaction of Execute.
+func (p *Contract) AddInvariant(name string, check func(p *Contract) error) {
+	p.Invariants = append(p.Invariants, Invariant{Name: name, Check: check})
+}
+
+//CheckInvariants checks all invariants on the latest block, and returns the first one violated.
+func (p *Contract) CheckInvariants() (string, error) {
+	for _, invariant := range p.Invariants {
+		if err := invariant.Check(p); err != nil {
+			return invariant.Name, err
+		}
+	}
+	return "", nil
+}
+
+//checkInvariants is called by Execute after the transaction is mined.
+//The first violation is kept in Violation, with the storage diff of the transaction.
+func (p *Contract) checkInvariants(method string, txHash common.Hash, block *big.Int, trace *Trace) error {
+	name, err := p.CheckInvariants()
+	if err == nil {
+		return nil
+	}
+	if trace == nil {
+		t, e := p.TraceTransaction(txHash, stepsLogConfig)
+		if e != nil {
+			return e
+		}
+		trace = t
+	}
+	diff, e := p.StorageDiff(trace, block)
+	if e != nil {
+		return e
+	}
+	violation := &InvariantViolation{Invariant: name, Method: method, TxHash: txHash, Block: block, Err: err, Diff: diff}
+	if p.Violation == nil {
+		p.Violation = violation
+	}
+	return violation
+}
+
+//StorageDiff returns slots of the contract written in the trace, whose values differ before and after the block.
+//Labels are filled in if the storage layout is available.
+func (p *Contract) StorageDiff(trace *Trace, block *big.Int) ([]StorageChange, error) {
+	ctx := context.Background()
+	parent := new(big.Int).Sub(block, common.Big1)
+
+	labels := map[common.Hash][]string{}
+	if layout, err := p.StorageLayout(); err == nil {
+		for _, v := range layout.Storage {
+			if slot, ok := new(big.Int).SetString(v.Slot, 10); ok {
+				labels[common.BigToHash(slot)] = append(labels[common.BigToHash(slot)], v.Label)
+			}
+		}
+	}
+
+	seen := map[common.Hash]bool{}
+	ret := []StorageChange{}
+	for _, access := range trace.StorageAccesses {
+		if access.Write == false || access.Address != p.Address || seen[access.Slot] {
+			continue
+		}
+		seen[access.Slot] = true
+		old, err := p.Backend.StorageAt(ctx, p.Address, access.Slot, parent)
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.Backend.StorageAt(ctx, p.Address, access.Slot, block)
+		if err != nil {
+			return nil, err
+		}
+		if common.BytesToHash(old) != common.BytesToHash(value) {
+			ret = append(ret, StorageChange{
+				Slot:   access.Slot,
+				Labels: labels[access.Slot],
+				Old:    common.BytesToHash(old),
+				New:    common.BytesToHash(value),
+			})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Slot.Big().Cmp(ret[j].Slot.Big()) < 0 })
+	return ret, nil
+}
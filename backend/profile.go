@@ -0,0 +1,122 @@
+package backend
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//GasProfile attributes gas of traced transactions to solidity call stacks and source lines.
+//Only gas of executed opcodes is counted; the intrinsic gas of transactions and refunds are not.
+type GasProfile struct {
+	//LineFrames adds the source line as the leaf frame of folded stacks.
+	LineFrames bool
+
+	mu     sync.Mutex
+	stacks map[string]uint64 //folded stack => gas
+	lines  map[string]*ProfileLine
+}
+
+//ProfileLine is gas used by a line of the source.
+type ProfileLine struct {
+	File     string
+	Line     int
+	Function string
+	Gas      uint64
+}
+
+//DefaultGasProfile is the gas profile which contracts made by NewContract record to. It is nil by default.
+var DefaultGasProfile *GasProfile
+
+//NewGasProfile returns an empty gas profile.
+func NewGasProfile() *GasProfile {
+	return &GasProfile{
+		stacks: make(map[string]uint64),
+		lines:  make(map[string]*ProfileLine),
+	}
+}
+
+//Record adds gas of steps executing the code at the address in a trace.
+//root is the bottom frame of every stack, and takes gas of steps outside functions like the dispatcher.
+func (p *GasProfile) Record(m *SourceMap, trace *Trace, address common.Address, root string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	m.Walk(trace, address, func(index int, loc *SourceLocation, stack []string) {
+		cost := trace.StructLogs[index].GasCost
+		frames := append([]string{root}, stack...)
+		if loc != nil {
+			if p.LineFrames {
+				frames = append(frames, fmt.Sprintf("%s:%d", filepath.Base(loc.File), loc.Line))
+			}
+			key := fmt.Sprintf("%s:%d", loc.File, loc.Line)
+			l, ok := p.lines[key]
+			if ok == false {
+				l = &ProfileLine{File: loc.File, Line: loc.Line, Function: loc.Function}
+				p.lines[key] = l
+			}
+			l.Gas += cost
+		}
+		p.stacks[strings.Join(frames, ";")] += cost
+	})
+}
+
+//WriteFolded writes the profile in the folded stack format, a "frame;frame;frame gas" line per stack,
+//which flamegraph.pl, inferno and speedscope can render.
+func (p *GasProfile) WriteFolded(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := make([]string, 0, len(p.stacks))
+	for k := range p.stacks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if p.stacks[k] == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s %d\n", k, p.stacks[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Lines returns gas used by each source line, the most expensive first.
+func (p *GasProfile) Lines() []ProfileLine {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := make([]ProfileLine, 0, len(p.lines))
+	for _, l := range p.lines {
+		ret = append(ret, *l)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Gas != ret[j].Gas {
+			return ret[i].Gas > ret[j].Gas
+		}
+		if ret[i].File != ret[j].File {
+			return ret[i].File < ret[j].File
+		}
+		return ret[i].Line < ret[j].Line
+	})
+	return ret
+}
+
+//Total returns gas of all recorded steps.
+func (p *GasProfile) Total() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	total := uint64(0)
+	for _, gas := range p.stacks {
+		total += gas
+	}
+	return total
+}
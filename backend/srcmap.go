@@ -0,0 +1,377 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+//SourceMap maps program counters of the runtime bytecode to ranges of the solidity source.
+type SourceMap struct {
+	File      string
+	Source    string
+	code      []byte
+	entries   []sourceMapEntry
+	pcIndex   map[uint64]int //pc => index of entries
+	lineStart []int          //offsets where each line starts
+	functions []sourceFunction
+}
+
+//SourceLocation is a range of the solidity source for a single instruction.
+type SourceLocation struct {
+	File     string
+	Start    int
+	Length   int
+	Line     int //1-based
+	Column   int //1-based
+	Snippet  string
+	Function string //function or modifier containing the range, empty if none
+	Jump     byte   //'i' jumps into a function, 'o' returns from a function, '-' otherwise
+}
+
+type sourceMapEntry struct {
+	start, length, file int
+	jump                byte
+}
+
+type sourceFunction struct {
+	name       string
+	start, end int //range from the keyword to the closing brace
+}
+
+//SourceMap returns the source map of the runtime bytecode, and caches it.
+func (p *Contract) SourceMap() (*SourceMap, error) {
+	if p.sourceMap != nil {
+		return p.sourceMap, nil
+	}
+	if p.Info == nil || len(p.RuntimeCode) == 0 {
+		return nil, fmt.Errorf("%s has no runtime code", p.Name)
+	}
+	m, err := NewSourceMap(p.File, p.Info.Source, p.Info.SrcMapRuntime, p.RuntimeCode)
+	if err != nil {
+		return nil, err
+	}
+	p.sourceMap = m
+	return m, nil
+}
+
+//NewSourceMap parses a compressed solc source map of the given bytecode.
+//Only the first source file(index 0) is located, instructions of other files are unknown.
+func NewSourceMap(file, source, srcMap string, code []byte) (*SourceMap, error) {
+	m := &SourceMap{
+		File:    file,
+		Source:  source,
+		code:    code,
+		pcIndex: make(map[uint64]int),
+	}
+
+	//decompress "s:l:f:j;..." where an empty field keeps the previous value.
+	prev := sourceMapEntry{file: -1, jump: '-'}
+	for i, item := range strings.Split(srcMap, ";") {
+		e := prev
+		for n, field := range strings.Split(item, ":") {
+			if field == "" {
+				continue
+			}
+			if n == 3 {
+				e.jump = field[0]
+				continue
+			}
+			if n > 3 {
+				break //modifier depth
+			}
+			v, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid source map entry %d: %q", i, item)
+			}
+			switch n {
+			case 0:
+				e.start = v
+			case 1:
+				e.length = v
+			case 2:
+				e.file = v
+			}
+		}
+		m.entries = append(m.entries, e)
+		prev = e
+	}
+
+	//an entry per instruction, PUSHn takes n bytes of immediate data.
+	for pc, index := uint64(0), 0; pc < uint64(len(code)) && index < len(m.entries); index++ {
+		m.pcIndex[pc] = index
+		op := vm.OpCode(code[pc])
+		if op >= vm.PUSH1 && op <= vm.PUSH32 {
+			pc += uint64(op-vm.PUSH1) + 1
+		}
+		pc++
+	}
+
+	m.lineStart = []int{0}
+	for i := 0; i < len(source); i++ {
+		if source[i] == '\n' {
+			m.lineStart = append(m.lineStart, i+1)
+		}
+	}
+	m.functions = scanFunctions(source)
+	return m, nil
+}
+
+//Lookup returns the source location of an instruction, or nil if it is not from the source.
+func (p *SourceMap) Lookup(pc uint64) *SourceLocation {
+	index, ok := p.pcIndex[pc]
+	if ok == false {
+		return nil
+	}
+	e := p.entries[index]
+	if e.file != 0 || e.start < 0 || e.start+e.length > len(p.Source) {
+		return nil
+	}
+	line, column := p.Position(e.start)
+	return &SourceLocation{
+		File:     p.File,
+		Start:    e.start,
+		Length:   e.length,
+		Line:     line,
+		Column:   column,
+		Snippet:  p.Source[e.start : e.start+e.length],
+		Function: p.FunctionAt(e.start),
+		Jump:     e.jump,
+	}
+}
+
+//Op returns the opcode at the pc of the bytecode.
+func (p *SourceMap) Op(pc uint64) vm.OpCode {
+	if pc >= uint64(len(p.code)) {
+		return vm.STOP
+	}
+	return vm.OpCode(p.code[pc])
+}
+
+//Pcs returns program counters of all instructions which have an entry in the source map, in order.
+func (p *SourceMap) Pcs() []uint64 {
+	ret := make([]uint64, 0, len(p.pcIndex))
+	for pc := range p.pcIndex {
+		ret = append(ret, pc)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+//Position returns the 1-based line and column of an offset of the source.
+func (p *SourceMap) Position(offset int) (int, int) {
+	line := sort.Search(len(p.lineStart), func(i int) bool { return p.lineStart[i] > offset })
+	return line, offset - p.lineStart[line-1] + 1
+}
+
+//Line returns the text of a 1-based line of the source.
+func (p *SourceMap) Line(line int) string {
+	if line < 1 || line > len(p.lineStart) {
+		return ""
+	}
+	end := len(p.Source)
+	if line < len(p.lineStart) {
+		end = p.lineStart[line] - 1
+	}
+	return strings.TrimRight(p.Source[p.lineStart[line-1]:end], "\r")
+}
+
+//FunctionAt returns the name of the innermost function or modifier containing an offset of the source.
+func (p *SourceMap) FunctionAt(offset int) string {
+	name, start := "", -1
+	for _, f := range p.functions {
+		if f.start <= offset && offset < f.end && f.start > start {
+			name, start = f.name, f.start
+		}
+	}
+	return name
+}
+
+//Walk visits steps of a trace executing the code at the address, with their source location
+//and the solidity call stack. Internal calls are followed by the jump types of the source map,
+//and the function containing a location is added on top of the stack when it is not there,
+//which is how inlined modifiers show up. loc may be nil for instructions not from the source.
+func (p *SourceMap) Walk(trace *Trace, address common.Address, fn func(index int, loc *SourceLocation, stack []string)) {
+	stack := []string{}
+	entering := false
+	for i := range trace.StructLogs {
+		step := &trace.StructLogs[i]
+		if step.Address != address {
+			continue
+		}
+		loc := p.Lookup(step.Pc)
+
+		current := stack
+		if loc != nil && loc.Function != "" {
+			if entering || len(stack) == 0 {
+				stack = append(stack, loc.Function)
+				current = stack
+			} else if stack[len(stack)-1] != loc.Function {
+				current = append(append([]string{}, stack...), loc.Function)
+			}
+		}
+		entering = false
+		fn(i, loc, current)
+
+		if loc != nil && step.Op == vm.JUMP.String() {
+			switch loc.Jump {
+			case 'i':
+				entering = true
+			case 'o':
+				if len(stack) > 1 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+	}
+}
+
+//scanFunctions finds ranges of functions, modifiers and constructors in solidity source,
+//skipping comments and string literals.
+func scanFunctions(source string) []sourceFunction {
+	//blank out comments and strings so that braces and keywords in them are ignored.
+	code := []byte(source)
+	for i := 0; i < len(code); i++ {
+		switch {
+		case code[i] == '/' && i+1 < len(code) && code[i+1] == '/':
+			for ; i < len(code) && code[i] != '\n'; i++ {
+				code[i] = ' '
+			}
+		case code[i] == '/' && i+1 < len(code) && code[i+1] == '*':
+			for ; i < len(code) && !(code[i] == '*' && i+1 < len(code) && code[i+1] == '/'); i++ {
+				code[i] = ' '
+			}
+			if i+1 < len(code) {
+				code[i], code[i+1] = ' ', ' '
+				i++
+			}
+		case code[i] == '"' || code[i] == '\'':
+			quote := code[i]
+			for i++; i < len(code) && code[i] != quote; i++ {
+				if code[i] == '\\' {
+					code[i] = ' '
+					i++
+				}
+				if i < len(code) {
+					code[i] = ' '
+				}
+			}
+		}
+	}
+	text := string(code)
+
+	isIdent := func(c byte) bool {
+		return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+
+	ret := []sourceFunction{}
+	for _, keyword := range []string{"function", "modifier", "constructor", "fallback", "receive"} {
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], keyword)
+			if i < 0 {
+				break
+			}
+			start := offset + i
+			offset = start + len(keyword)
+			if (start > 0 && isIdent(text[start-1])) || (offset < len(text) && isIdent(text[offset])) {
+				continue
+			}
+
+			name := keyword
+			if keyword == "function" || keyword == "modifier" {
+				j := offset
+				for j < len(text) && (text[j] == ' ' || text[j] == '\t' || text[j] == '\r' || text[j] == '\n') {
+					j++
+				}
+				k := j
+				for k < len(text) && isIdent(text[k]) {
+					k++
+				}
+				if k == j {
+					continue //function type, not a definition
+				}
+				name = text[j:k]
+			}
+
+			//the body starts at the first brace, declarations without a body end with a semicolon.
+			open := strings.IndexAny(text[offset:], "{;")
+			if open < 0 || text[offset+open] == ';' {
+				continue
+			}
+			depth := 0
+			for j := offset + open; j < len(text); j++ {
+				if text[j] == '{' {
+					depth++
+				} else if text[j] == '}' {
+					depth--
+					if depth == 0 {
+						ret = append(ret, sourceFunction{name: name, start: start, end: j + 1})
+						break
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
+
+//RevertError is a failed transaction located in the solidity source.
+type RevertError struct {
+	Reason  string   //reason string given to require or revert
+	File    string   //source file
+	Line    int      //1-based line of the failed statement
+	Column  int      //1-based column of the failed statement
+	Snippet string   //source of the failed statement
+	Stack   []string //solidity call stack, the outermost function first
+	Trace   *Trace
+}
+
+func (e *RevertError) Error() string {
+	msg := "execution reverted"
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.File == "" {
+		return msg
+	}
+	snippet := e.Snippet
+	if i := strings.IndexByte(snippet, '\n'); i >= 0 {
+		snippet = snippet[:i] + " ..."
+	}
+	return fmt.Sprintf("%s\n  at %s:%d:%d (%s)\n  %s", msg, e.File, e.Line, e.Column, strings.Join(e.Stack, " -> "), snippet)
+}
+
+//RevertError locates the revert point of a failed trace in the source.
+//It returns nil if the trace did not fail.
+func (p *Contract) RevertError(trace *Trace) *RevertError {
+	if trace == nil || trace.Failed == false {
+		return nil
+	}
+	ret := &RevertError{
+		Reason: trace.RevertReason,
+		Trace:  trace,
+	}
+	if trace.Revert == nil {
+		return ret
+	}
+
+	m, err := p.SourceMap()
+	if err != nil {
+		return ret
+	}
+	m.Walk(trace, p.Address, func(index int, loc *SourceLocation, stack []string) {
+		if &trace.StructLogs[index] != trace.Revert || loc == nil {
+			return
+		}
+		ret.File = loc.File
+		ret.Line = loc.Line
+		ret.Column = loc.Column
+		ret.Snippet = loc.Snippet
+		ret.Stack = append([]string{}, stack...)
+	})
+	return ret
+}
@@ -0,0 +1,393 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os/exec"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/compiler"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//StorageLayout is the storage layout of a contract emitted by solc --storage-layout.
+type StorageLayout struct {
+	Storage []StorageVariable       `json:"storage"`
+	Types   map[string]*StorageType `json:"types"`
+}
+
+//StorageVariable is a state variable or a struct member in the storage layout.
+type StorageVariable struct {
+	Label    string `json:"label"`
+	Contract string `json:"contract"`
+	Offset   int    `json:"offset"`
+	Slot     string `json:"slot"`
+	Type     string `json:"type"`
+}
+
+//StorageType describes how a type in the storage layout is encoded.
+//Encoding is one of "inplace", "mapping", "dynamic_array" and "bytes".
+type StorageType struct {
+	Encoding      string            `json:"encoding"`
+	Label         string            `json:"label"`
+	NumberOfBytes string            `json:"numberOfBytes"`
+	Key           string            `json:"key,omitempty"`
+	Value         string            `json:"value,omitempty"`
+	Base          string            `json:"base,omitempty"`
+	Members       []StorageVariable `json:"members,omitempty"`
+}
+
+//StorageLocation points at a value in the contract storage.
+type StorageLocation struct {
+	Slot   common.Hash
+	Offset int //byte offset in the slot, counted from the right
+	Type   *StorageType
+}
+
+//StorageLayout returns the storage layout of the contract.
+//It runs solc once more with --storage-layout, which needs solc 0.6.5 or later, and caches the result.
+func (p *Contract) StorageLayout() (*StorageLayout, error) {
+	if p.storageLayout != nil {
+		return p.storageLayout, nil
+	}
+
+	s, err := compiler.SolidityVersion("")
+	if err != nil {
+		return nil, err
+	}
+	if s.Major == 0 && (s.Minor < 6 || (s.Minor == 6 && s.Patch < 5)) {
+		return nil, fmt.Errorf("solc %s does not support --storage-layout", s.Version)
+	}
+
+	var stderr, stdout bytes.Buffer
+	cmd := exec.Command(s.Path, "--combined-json", "storage-layout", "--allow-paths", "., ./, ../", "--", p.File)
+	cmd.Stderr = &stderr
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("solc: %v\n%s", err, stderr.Bytes())
+	}
+
+	output := struct {
+		Contracts map[string]struct {
+			StorageLayout json.RawMessage `json:"storage-layout"`
+		}
+	}{}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, err
+	}
+	contract, ok := output.Contracts[fmt.Sprintf("%s:%s", p.File, p.Name)]
+	if ok == false {
+		return nil, fmt.Errorf("%s contract is not here", p.Name)
+	}
+
+	//older solc versions embed the layout as a json string.
+	raw := []byte(contract.StorageLayout)
+	if len(raw) > 0 && raw[0] == '"' {
+		str := ""
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, err
+		}
+		raw = []byte(str)
+	}
+
+	layout := &StorageLayout{}
+	if err := json.Unmarshal(raw, layout); err != nil {
+		return nil, err
+	}
+	p.storageLayout = layout
+	return layout, nil
+}
+
+//Locate computes the storage location of a state variable.
+//keys walk into the variable: a key of a mapping, an index of an array, or a member name of a struct.
+//Keys of mappings and indexes of arrays may be common.Address, *big.Int, int, uint64, bool, string or []byte.
+func (p *StorageLayout) Locate(label string, keys ...interface{}) (*StorageLocation, error) {
+	variable := (*StorageVariable)(nil)
+	for i := range p.Storage {
+		if p.Storage[i].Label == label {
+			variable = &p.Storage[i]
+			break
+		}
+	}
+	if variable == nil {
+		return nil, fmt.Errorf("storage variable %s is not here", label)
+	}
+
+	loc, err := p.member(common.Hash{}, variable)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		switch loc.Type.Encoding {
+		case "mapping":
+			keyType, err := p.typeOf(loc.Type.Key)
+			if err != nil {
+				return nil, err
+			}
+			encoded, err := encodeStorageKey(keyType, key)
+			if err != nil {
+				return nil, err
+			}
+			valueType, err := p.typeOf(loc.Type.Value)
+			if err != nil {
+				return nil, err
+			}
+			loc = &StorageLocation{
+				Slot: crypto.Keccak256Hash(encoded, loc.Slot.Bytes()),
+				Type: valueType,
+			}
+
+		case "dynamic_array":
+			if loc, err = p.element(crypto.Keccak256Hash(loc.Slot.Bytes()), loc.Type, key); err != nil {
+				return nil, err
+			}
+
+		case "inplace":
+			if loc.Type.Base != "" { //static array
+				if loc, err = p.element(loc.Slot, loc.Type, key); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			name, ok := key.(string)
+			if ok == false || len(loc.Type.Members) == 0 {
+				return nil, fmt.Errorf("%s does not have a member %v", loc.Type.Label, key)
+			}
+			found := false
+			for i := range loc.Type.Members {
+				if loc.Type.Members[i].Label == name {
+					if loc, err = p.member(loc.Slot, &loc.Type.Members[i]); err != nil {
+						return nil, err
+					}
+					found = true
+					break
+				}
+			}
+			if found == false {
+				return nil, fmt.Errorf("%s does not have a member %s", loc.Type.Label, name)
+			}
+
+		default:
+			return nil, fmt.Errorf("%s can not be indexed", loc.Type.Label)
+		}
+	}
+	return loc, nil
+}
+
+func (p *StorageLayout) typeOf(id string) (*StorageType, error) {
+	t, ok := p.Types[id]
+	if ok == false {
+		return nil, fmt.Errorf("storage type %s is not here", id)
+	}
+	return t, nil
+}
+
+//member returns the location of a variable placed relative to the base slot.
+func (p *StorageLayout) member(base common.Hash, v *StorageVariable) (*StorageLocation, error) {
+	slot, ok := new(big.Int).SetString(v.Slot, 10)
+	if ok == false {
+		return nil, fmt.Errorf("invalid slot %s of %s", v.Slot, v.Label)
+	}
+	t, err := p.typeOf(v.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageLocation{
+		Slot:   addSlot(base, slot),
+		Offset: v.Offset,
+		Type:   t,
+	}, nil
+}
+
+//element returns the location of an array element whose data starts at the base slot.
+func (p *StorageLayout) element(base common.Hash, array *StorageType, index interface{}) (*StorageLocation, error) {
+	i, err := toStorageBig(index)
+	if err != nil {
+		return nil, err
+	}
+	t, err := p.typeOf(array.Base)
+	if err != nil {
+		return nil, err
+	}
+	size, ok := new(big.Int).SetString(t.NumberOfBytes, 10)
+	if ok == false {
+		return nil, fmt.Errorf("invalid size %s of %s", t.NumberOfBytes, t.Label)
+	}
+
+	//elements of 32 bytes or more take whole slots, smaller ones are packed.
+	if size.Int64() >= 32 {
+		slots := new(big.Int).Div(new(big.Int).Add(size, big.NewInt(31)), big.NewInt(32))
+		return &StorageLocation{
+			Slot: addSlot(base, new(big.Int).Mul(i, slots)),
+			Type: t,
+		}, nil
+	}
+	perSlot := big.NewInt(32 / size.Int64())
+	quo, rem := new(big.Int).QuoRem(i, perSlot, new(big.Int))
+	return &StorageLocation{
+		Slot:   addSlot(base, quo),
+		Offset: int(rem.Int64() * size.Int64()),
+		Type:   t,
+	}, nil
+}
+
+//ReadStorage reads a state variable from the contract storage at the given block(nil is the latest block),
+//and decodes it to a Go value.
+//Integers are decoded to *big.Int, address to common.Address, bool to bool, fixed bytes to []byte,
+//string to string, bytes to []byte, dynamic arrays to their length as *big.Int,
+//and structs to map[string]interface{} keyed by member name.
+func (p *Contract) ReadStorage(block *big.Int, label string, keys ...interface{}) (interface{}, error) {
+	layout, err := p.StorageLayout()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := layout.Locate(label, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.readLocation(block, layout, loc)
+}
+
+func (p *Contract) readLocation(block *big.Int, layout *StorageLayout, loc *StorageLocation) (interface{}, error) {
+	word, err := p.Backend.StorageAt(context.Background(), p.Address, loc.Slot, block)
+	if err != nil {
+		return nil, err
+	}
+
+	switch loc.Type.Encoding {
+	case "inplace":
+		if len(loc.Type.Members) > 0 {
+			ret := make(map[string]interface{})
+			for i := range loc.Type.Members {
+				m, err := layout.member(loc.Slot, &loc.Type.Members[i])
+				if err != nil {
+					return nil, err
+				}
+				if ret[loc.Type.Members[i].Label], err = p.readLocation(block, layout, m); err != nil {
+					return nil, err
+				}
+			}
+			return ret, nil
+		}
+		size, ok := new(big.Int).SetString(loc.Type.NumberOfBytes, 10)
+		if ok == false || size.Int64() > 32 || int64(loc.Offset)+size.Int64() > 32 {
+			return nil, fmt.Errorf("can not decode %s", loc.Type.Label)
+		}
+		return decodeStorageValue(loc.Type.Label, word[32-loc.Offset-int(size.Int64()):32-loc.Offset])
+
+	case "dynamic_array":
+		return new(big.Int).SetBytes(word), nil
+
+	case "bytes":
+		data, err := p.readBytes(block, loc.Slot, word)
+		if err != nil {
+			return nil, err
+		}
+		if loc.Type.Label == "string" {
+			return string(data), nil
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("can not decode %s without a key", loc.Type.Label)
+}
+
+//readBytes reads a string or bytes variable.
+//Up to 31 bytes are stored in the slot with length*2 in the lowest byte,
+//longer ones store length*2+1 in the slot and the data from keccak256(slot).
+func (p *Contract) readBytes(block *big.Int, slot common.Hash, word []byte) ([]byte, error) {
+	if word[31]&1 == 0 {
+		return common.CopyBytes(word[:word[31]/2]), nil
+	}
+
+	length := new(big.Int).Rsh(new(big.Int).SetBytes(word), 1).Uint64()
+	data := make([]byte, 0, length)
+	base := crypto.Keccak256Hash(slot.Bytes())
+	for i := uint64(0); uint64(len(data)) < length; i++ {
+		chunk, err := p.Backend.StorageAt(context.Background(), p.Address, addSlot(base, new(big.Int).SetUint64(i)), block)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+	}
+	return data[:length], nil
+}
+
+//decodeStorageValue decodes a value type by its solidity type label.
+func decodeStorageValue(label string, b []byte) (interface{}, error) {
+	switch {
+	case label == "bool":
+		return new(big.Int).SetBytes(b).Sign() != 0, nil
+	case strings.HasPrefix(label, "address"), strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(b), nil
+	case strings.HasPrefix(label, "uint"), strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(b), nil
+	case strings.HasPrefix(label, "int"):
+		v := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return v, nil
+	case strings.HasPrefix(label, "bytes"):
+		return common.CopyBytes(b), nil
+	}
+	return nil, fmt.Errorf("can not decode %s", label)
+}
+
+//encodeStorageKey encodes a mapping key the way solidity hashes it with the slot.
+func encodeStorageKey(t *StorageType, key interface{}) ([]byte, error) {
+	if t.Encoding == "bytes" {
+		switch k := key.(type) {
+		case string:
+			return []byte(k), nil
+		case []byte:
+			return k, nil
+		}
+		return nil, fmt.Errorf("invalid key %v for %s", key, t.Label)
+	}
+
+	switch k := key.(type) {
+	case common.Address:
+		return common.LeftPadBytes(k.Bytes(), 32), nil
+	case bool:
+		if k {
+			return common.LeftPadBytes([]byte{1}, 32), nil
+		}
+		return make([]byte, 32), nil
+	case []byte:
+		//fixed bytes are left aligned
+		return common.RightPadBytes(k, 32), nil
+	}
+
+	v, err := toStorageBig(key)
+	if err != nil {
+		return nil, err
+	}
+	if v.Sign() < 0 {
+		v = new(big.Int).Add(v, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return common.LeftPadBytes(v.Bytes(), 32), nil
+}
+
+func toStorageBig(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case *big.Int:
+		return n, nil
+	case int:
+		return big.NewInt(int64(n)), nil
+	case int64:
+		return big.NewInt(n), nil
+	case uint64:
+		return new(big.Int).SetUint64(n), nil
+	}
+	return nil, fmt.Errorf("invalid index %v", v)
+}
+
+func addSlot(base common.Hash, n *big.Int) common.Hash {
+	sum := new(big.Int).Add(base.Big(), n)
+	return common.BigToHash(sum)
+}
@@ -0,0 +1,438 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	gomath "math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+//Trace is a structured trace of a transaction replayed with a vm.Tracer.
+//Its json encoding follows the struct logger output of geth's debug_traceTransaction.
+type Trace struct {
+	Gas         uint64      `json:"gas"`
+	Failed      bool        `json:"failed"`
+	ReturnValue string      `json:"returnValue"`
+	StructLogs  []TraceStep `json:"structLogs"`
+
+	TxHash          common.Hash     `json:"-"`
+	Call            *CallFrame      `json:"-"` //top level call frame
+	StorageAccesses []StorageAccess `json:"-"` //SLOAD and SSTORE in execution order
+	Revert          *TraceStep      `json:"-"` //step where the execution failed, nil if succeeded or truncated
+	Truncated       bool            `json:"-"` //steps after LogConfig.Limit were not recorded
+	RevertReason    string          `json:"-"` //reason string given to require or revert
+}
+
+//TraceStep is a single opcode step of a trace.
+type TraceStep struct {
+	Pc      uint64             `json:"pc"`
+	Op      string             `json:"op"`
+	Gas     uint64             `json:"gas"`
+	GasCost uint64             `json:"gasCost"`
+	Depth   int                `json:"depth"`
+	Error   string             `json:"error,omitempty"`
+	Stack   *[]string          `json:"stack,omitempty"`
+	Memory  *[]string          `json:"memory,omitempty"`
+	Storage *map[string]string `json:"storage,omitempty"`
+
+	Address common.Address `json:"-"` //address of the code being executed
+}
+
+//CallFrame is a message call made during a transaction.
+//Calls to precompiled contracts and accounts without code execute no steps and are not recorded.
+type CallFrame struct {
+	Type      string //CALL, STATICCALL, DELEGATECALL, CALLCODE, CREATE or CREATE2
+	From      common.Address
+	To        common.Address //address of the code being executed
+	Value     *big.Int
+	Gas       uint64
+	Input     []byte
+	Output    []byte //return data, only known for the top level call
+	Error     string
+	Depth     int
+	FirstStep int //index of the first step in StructLogs
+	LastStep  int //index of the last step in StructLogs
+	Calls     []*CallFrame
+}
+
+//StorageAccess is a read or a write of a storage slot.
+type StorageAccess struct {
+	Step    int //index of the step in StructLogs
+	Address common.Address
+	Slot    common.Hash
+	Value   common.Hash //value read by SLOAD or written by SSTORE
+	Write   bool
+}
+
+//WriteJSON writes the trace in the json format of debug_traceTransaction.
+func (p *Trace) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(p)
+}
+
+//TraceTransaction replays a mined transaction on the state before it and returns its trace.
+//cfg selects what is captured in each step, nil captures everything.
+func (p *Contract) TraceTransaction(hash common.Hash, cfg *vm.LogConfig) (*Trace, error) {
+	receipt, err := p.Backend.TransactionReceipt(context.Background(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	bc := p.Backend.Blockchain()
+	block := bc.GetBlockByHash(receipt.BlockHash)
+	if block == nil {
+		return nil, fmt.Errorf("block %x is not here", receipt.BlockHash)
+	}
+	parent := bc.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent of block %x is not here", receipt.BlockHash)
+	}
+	statedb, err := bc.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := newTraceLogger(cfg)
+	gp := new(core.GasPool).AddGas(block.GasLimit())
+	usedGas := uint64(0)
+
+	//replay transactions in the block until the one to trace.
+	for i, tx := range block.Transactions() {
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+
+		vmConfig := vm.Config{}
+		if tx.Hash() == hash {
+			vmConfig = vm.Config{Debug: true, Tracer: tracer}
+		}
+		r, err := core.ApplyTransaction(bc.Config(), bc, nil, gp, statedb, block.Header(), tx, &usedGas, vmConfig)
+		if err != nil {
+			return nil, err
+		}
+		if tx.Hash() == hash {
+			trace := tracer.trace()
+			trace.TxHash = hash
+			trace.Gas = r.GasUsed
+			trace.Failed = r.Status != 1
+			return trace, nil
+		}
+	}
+	return nil, fmt.Errorf("transaction %x is not in block %x", hash, receipt.BlockHash)
+}
+
+//traceLogger implements vm.Tracer to build a Trace.
+type traceLogger struct {
+	cfg vm.LogConfig
+
+	steps    []TraceStep
+	accesses []StorageAccess
+	storage  map[common.Address]map[common.Hash]common.Hash //slots touched so far, for the storage of each step
+
+	root      *CallFrame
+	frames    []*CallFrame //frames being executed, the last is the current one
+	callOp    string       //call opcode executed by the previous step
+	faulted   int          //index of a step which raised an error, -1 if none
+	truncated bool         //a step was not recorded for the limit, so later faults belong to no recorded step
+
+	output []byte
+	err    error
+}
+
+func newTraceLogger(cfg *vm.LogConfig) *traceLogger {
+	l := &traceLogger{
+		storage: make(map[common.Address]map[common.Hash]common.Hash),
+		faulted: -1,
+	}
+	if cfg != nil {
+		l.cfg = *cfg
+	}
+	return l
+}
+
+//CaptureStart implements the vm.Tracer interface to open the top level call frame.
+func (l *traceLogger) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := "CALL"
+	if create {
+		typ = "CREATE"
+	}
+	l.root = &CallFrame{
+		Type:  typ,
+		From:  from,
+		To:    to,
+		Value: new(big.Int).Set(value),
+		Gas:   gas,
+		Input: common.CopyBytes(input),
+		Depth: 1,
+	}
+	l.frames = []*CallFrame{l.root}
+	return nil
+}
+
+//CaptureState implements the vm.Tracer interface to record a step.
+func (l *traceLogger) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, rStack *vm.ReturnStack, contract *vm.Contract, depth int, err error) error {
+	if l.cfg.Limit != 0 && l.cfg.Limit <= len(l.steps) {
+		l.truncated = true
+		return nil
+	}
+	index := len(l.steps)
+	address := contract.Address()
+	if contract.CodeAddr != nil {
+		address = *contract.CodeAddr
+	}
+
+	l.enter(index, depth, address, contract)
+
+	step := TraceStep{
+		Pc:      pc,
+		Op:      op.String(),
+		Gas:     gas,
+		GasCost: cost,
+		Depth:   depth,
+		Address: address,
+	}
+	if err != nil {
+		//errors raised before the step is executed, e.g. stack underflow, come here.
+		step.Error = err.Error()
+		l.fault(index, err)
+	}
+
+	data := stack.Data()
+	switch op {
+	case vm.SLOAD:
+		if len(data) >= 1 {
+			slot := common.BigToHash(data[len(data)-1])
+			l.touch(index, contract.Address(), slot, env.StateDB.GetState(contract.Address(), slot), false)
+		}
+	case vm.SSTORE:
+		if len(data) >= 2 {
+			l.touch(index, contract.Address(), common.BigToHash(data[len(data)-1]), common.BigToHash(data[len(data)-2]), true)
+		}
+	}
+
+	if l.cfg.DisableStack == false {
+		s := make([]string, len(data))
+		for i, v := range data {
+			s[i] = fmt.Sprintf("%x", math.PaddedBigBytes(v, 32))
+		}
+		step.Stack = &s
+	}
+	if l.cfg.DisableMemory == false {
+		mem := memory.Data()
+		m := make([]string, 0, (len(mem)+31)/32)
+		for i := 0; i+32 <= len(mem); i += 32 {
+			m = append(m, fmt.Sprintf("%x", mem[i:i+32]))
+		}
+		step.Memory = &m
+	}
+	if l.cfg.DisableStorage == false && (op == vm.SLOAD || op == vm.SSTORE) {
+		s := make(map[string]string)
+		for k, v := range l.storage[contract.Address()] {
+			s[fmt.Sprintf("%x", k)] = fmt.Sprintf("%x", v)
+		}
+		step.Storage = &s
+	}
+
+	switch op {
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL, vm.CREATE, vm.CREATE2:
+		l.callOp = op.String()
+	default:
+		l.callOp = ""
+	}
+	if op == vm.REVERT {
+		l.current().Error = "execution reverted"
+	}
+
+	l.steps = append(l.steps, step)
+	return nil
+}
+
+//CaptureFault implements the vm.Tracer interface to mark the step which raised an error.
+func (l *traceLogger) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, rStack *vm.ReturnStack, contract *vm.Contract, depth int, err error) error {
+	if len(l.steps) == 0 || l.truncated {
+		return nil
+	}
+	l.steps[len(l.steps)-1].Error = err.Error()
+	l.fault(len(l.steps)-1, err)
+	return nil
+}
+
+//CaptureEnd implements the vm.Tracer interface to close the top level call frame.
+func (l *traceLogger) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	l.output = common.CopyBytes(output)
+	l.err = err
+	return nil
+}
+
+//enter keeps frames in step with the depth of the step to be recorded.
+func (l *traceLogger) enter(index, depth int, address common.Address, contract *vm.Contract) {
+	for len(l.frames) > 0 && l.current().Depth > depth {
+		l.current().LastStep = index - 1
+		l.frames = l.frames[:len(l.frames)-1]
+	}
+	if f := l.current(); f != nil && f.Depth < depth {
+		frame := &CallFrame{
+			Type:      l.callOp,
+			From:      contract.Caller(),
+			To:        address,
+			Value:     new(big.Int).Set(contract.Value()),
+			Gas:       contract.Gas,
+			Input:     common.CopyBytes(contract.Input),
+			Depth:     depth,
+			FirstStep: index,
+		}
+		f.Calls = append(f.Calls, frame)
+		l.frames = append(l.frames, frame)
+	}
+}
+
+func (l *traceLogger) fault(index int, err error) {
+	l.faulted = index
+	if f := l.current(); f != nil {
+		f.Error = err.Error()
+	}
+}
+
+func (l *traceLogger) current() *CallFrame {
+	if len(l.frames) == 0 {
+		return nil
+	}
+	return l.frames[len(l.frames)-1]
+}
+
+func (l *traceLogger) touch(index int, address common.Address, slot, value common.Hash, write bool) {
+	if l.storage[address] == nil {
+		l.storage[address] = make(map[common.Hash]common.Hash)
+	}
+	l.storage[address][slot] = value
+	l.accesses = append(l.accesses, StorageAccess{
+		Step:    index,
+		Address: address,
+		Slot:    slot,
+		Value:   value,
+		Write:   write,
+	})
+}
+
+//trace assembles the Trace from the recorded steps.
+func (l *traceLogger) trace() *Trace {
+	ret := &Trace{
+		ReturnValue:     fmt.Sprintf("%x", l.output),
+		StructLogs:      l.steps,
+		Call:            l.root,
+		StorageAccesses: l.accesses,
+		Truncated:       l.truncated,
+	}
+	if ret.StructLogs == nil {
+		ret.StructLogs = []TraceStep{}
+	}
+	for len(l.frames) > 0 {
+		l.current().LastStep = len(l.steps) - 1
+		l.frames = l.frames[:len(l.frames)-1]
+	}
+	if l.root == nil {
+		return ret
+	}
+	l.root.Output = l.output
+
+	if l.err == nil {
+		l.root.Error = ""
+		return ret
+	}
+	l.root.Error = l.err.Error()
+	if l.truncated {
+		//the execution failed after the last step recorded
+		if reason, err := abi.UnpackRevert(l.output); err == nil {
+			ret.RevertReason = reason
+		}
+		return ret
+	}
+
+	//the revert point is the step of the top level frame which raised the error,
+	//or the last REVERT of the top level frame, or a step of an inner frame which raised the error.
+	if l.faulted >= 0 && ret.StructLogs[l.faulted].Depth == 1 {
+		ret.Revert = &ret.StructLogs[l.faulted]
+	} else {
+		for i := len(ret.StructLogs) - 1; i >= 0; i-- {
+			if ret.StructLogs[i].Depth == 1 && ret.StructLogs[i].Op == vm.REVERT.String() {
+				ret.Revert = &ret.StructLogs[i]
+				break
+			}
+		}
+		if ret.Revert == nil && l.faulted >= 0 {
+			ret.Revert = &ret.StructLogs[l.faulted]
+		}
+	}
+	if reason, err := abi.UnpackRevert(l.output); err == nil {
+		ret.RevertReason = reason
+	}
+	return ret
+}
+
+//TraceCall executes a call on the state of the given block(nil is the latest block) without making a transaction,
+//and returns its output and trace. A reverted call returns *RevertError.
+func (p *Contract) TraceCall(call ethereum.CallMsg, block *big.Int, cfg *vm.LogConfig) ([]byte, *Trace, error) {
+	bc := p.Backend.Blockchain()
+	header := bc.CurrentHeader()
+	if block != nil {
+		if header = bc.GetHeaderByNumber(block.Uint64()); header == nil {
+			return nil, nil, fmt.Errorf("block %v is not here", block)
+		}
+	}
+	statedb, err := bc.StateAt(header.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//same defaults as the simulated backend
+	if call.GasPrice == nil {
+		call.GasPrice = big.NewInt(1)
+	}
+	if call.Gas == 0 {
+		call.Gas = 50000000
+	}
+	if call.Value == nil {
+		call.Value = new(big.Int)
+	}
+	statedb.GetOrNewStateObject(call.From).SetBalance(math.MaxBig256)
+
+	tracer := newTraceLogger(cfg)
+	msg := callMsg{call}
+	evm := vm.NewEVM(core.NewEVMContext(msg, header, bc, nil), statedb, bc.Config(), vm.Config{Debug: true, Tracer: tracer})
+	result, err := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(gomath.MaxUint64)).TransitionDb()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trace := tracer.trace()
+	trace.Gas = result.UsedGas
+	trace.Failed = result.Failed()
+	if result.Failed() {
+		if e := p.RevertError(trace); e != nil {
+			return nil, trace, e
+		}
+		return nil, trace, result.Err
+	}
+	return result.Return(), trace, nil
+}
+
+//callMsg implements core.Message for TraceCall.
+type callMsg struct {
+	ethereum.CallMsg
+}
+
+func (m callMsg) From() common.Address { return m.CallMsg.From }
+func (m callMsg) Nonce() uint64        { return 0 }
+func (m callMsg) CheckNonce() bool     { return false }
+func (m callMsg) To() *common.Address  { return m.CallMsg.To }
+func (m callMsg) GasPrice() *big.Int   { return m.CallMsg.GasPrice }
+func (m callMsg) Gas() uint64          { return m.CallMsg.Gas }
+func (m callMsg) Value() *big.Int      { return m.CallMsg.Value }
+func (m callMsg) Data() []byte         { return m.CallMsg.Data }
@@ -0,0 +1,76 @@
+//Command gen generates Go bindings of a contract from its ABI with go-ethereum's bind package,
+//the same generator as abigen.
+//
+//With -sol, the contract is compiled by solc and its ABI is written to the -abi file first.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/compiler"
+)
+
+func main() {
+	var (
+		sol  = flag.String("sol", "", "solidity source to refresh the abi file from, optional")
+		abi  = flag.String("abi", "", "abi file")
+		typ  = flag.String("type", "", "contract name, and Go type of the binding")
+		pkg  = flag.String("pkg", "", "Go package name")
+		out  = flag.String("out", "", "output file")
+		solc = flag.String("solc", "", "solc to use, solc in PATH by default")
+	)
+	flag.Parse()
+
+	if err := generate(*sol, *abi, *typ, *pkg, *out, *solc); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func generate(sol, abiFile, typ, pkg, out, solc string) error {
+	if abiFile == "" || typ == "" || pkg == "" || out == "" {
+		return fmt.Errorf("-abi, -type, -pkg and -out are required")
+	}
+
+	if sol != "" {
+		contracts, err := compiler.CompileSolidity(solc, sol)
+		if err != nil {
+			return err
+		}
+		contract, ok := contracts[fmt.Sprintf("%s:%s", sol, typ)]
+		if ok == false {
+			return fmt.Errorf("%s contract is not in %s", typ, sol)
+		}
+		b, err := json.MarshalIndent(contract.Info.AbiDefinition, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(abiFile, append(b, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+
+	//make the abi canonical, so that the binding does not change with formatting of the abi file.
+	b, err := ioutil.ReadFile(abiFile)
+	if err != nil {
+		return err
+	}
+	var definition interface{}
+	if err := json.Unmarshal(b, &definition); err != nil {
+		return err
+	}
+	if b, err = json.Marshal(definition); err != nil {
+		return err
+	}
+
+	code, err := bind.Bind([]string{typ}, []string{string(b)}, []string{""}, nil, pkg, bind.LangGo, nil, nil)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(out, []byte(code), 0644)
+}
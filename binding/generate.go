@@ -0,0 +1,5 @@
+//Package binding has typed Go bindings of the contracts, generated from their ABI.
+//They work against any bind.ContractBackend, like the simulated backend of backend.Contract or an ethclient.
+package binding
+
+//go:generate go run ./gen -sol ../contracts/WemixToken.sol -abi ../contracts/WemixToken.abi -type WemixToken -pkg binding -out wemixtoken.go
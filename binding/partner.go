@@ -0,0 +1,18 @@
+package binding
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//Partner is the block partner returned by PartnerByIndex, PartnerBySerial and AllPartners.
+//It is an alias of the struct type generated for their outputs.
+type Partner = struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}
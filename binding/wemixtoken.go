@@ -0,0 +1,2095 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package binding
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// WemixTokenABI is the input ABI used to generate the binding from.
+const WemixTokenABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_ecoFund\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_wemix\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"partner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"serial\",\"type\":\"uint256\"}],\"name\":\"Staked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"partner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"serial\",\"type\":\"uint256\"}],\"name\":\"Withdrawal\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"addAllowedPartner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"allPartners\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"serial\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"partner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"blockStaking\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockWaitingWithdrawal\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"balanceStaking\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"allowedPartners\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"blockToMint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"blockUnitForMint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_block\",\"type\":\"uint256\"}],\"name\":\"change_blockUnitForMint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"change_ecoFund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_block\",\"type\":\"uint256\"}],\"name\":\"change_minBlockWaitingWithdrawal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"}],\"name\":\"change_mintToEcoFund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"}],\"name\":\"change_mintToPartner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"}],\"name\":\"change_mintToWemix\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_unit\",\"type\":\"uint256\"}],\"name\":\"change_unitStaking\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"change_wemix\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"subtractedValue\",\"type\":\"uint256\"}],\"name\":\"decreaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ecoFund\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"addedValue\",\"type\":\"uint256\"}],\"name\":\"increaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isMintable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isOwner\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minBlockWaitingWithdrawal\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"mintToEcoFund\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"mintToPartner\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"mintToWemix\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nextPartnerToMint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_index\",\"type\":\"uint256\"}],\"name\":\"partnerByIndex\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"serial\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"partner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"blockStaking\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockWaitingWithdrawal\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"balanceStaking\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_serial\",\"type\":\"uint256\"}],\"name\":\"partnerBySerial\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"serial\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"partner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"blockStaking\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockWaitingWithdrawal\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"balanceStaking\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"partnersNumber\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"removeAllowedPartner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_withdrawalWaitingMinBlock\",\"type\":\"uint256\"}],\"name\":\"stake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_partner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_withdrawalWaitingMinBlock\",\"type\":\"uint256\"}],\"name\":\"stakeDelegated\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unitStaking\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"wemix\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_serial\",\"type\":\"uint256\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+// WemixToken is an auto generated Go binding around an Ethereum contract.
+type WemixToken struct {
+	WemixTokenCaller     // Read-only binding to the contract
+	WemixTokenTransactor // Write-only binding to the contract
+	WemixTokenFilterer   // Log filterer for contract events
+}
+
+// WemixTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type WemixTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WemixTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type WemixTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WemixTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type WemixTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WemixTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type WemixTokenSession struct {
+	Contract     *WemixToken       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// WemixTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type WemixTokenCallerSession struct {
+	Contract *WemixTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// WemixTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type WemixTokenTransactorSession struct {
+	Contract     *WemixTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// WemixTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type WemixTokenRaw struct {
+	Contract *WemixToken // Generic contract binding to access the raw methods on
+}
+
+// WemixTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type WemixTokenCallerRaw struct {
+	Contract *WemixTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// WemixTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type WemixTokenTransactorRaw struct {
+	Contract *WemixTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewWemixToken creates a new instance of WemixToken, bound to a specific deployed contract.
+func NewWemixToken(address common.Address, backend bind.ContractBackend) (*WemixToken, error) {
+	contract, err := bindWemixToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixToken{WemixTokenCaller: WemixTokenCaller{contract: contract}, WemixTokenTransactor: WemixTokenTransactor{contract: contract}, WemixTokenFilterer: WemixTokenFilterer{contract: contract}}, nil
+}
+
+// NewWemixTokenCaller creates a new read-only instance of WemixToken, bound to a specific deployed contract.
+func NewWemixTokenCaller(address common.Address, caller bind.ContractCaller) (*WemixTokenCaller, error) {
+	contract, err := bindWemixToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenCaller{contract: contract}, nil
+}
+
+// NewWemixTokenTransactor creates a new write-only instance of WemixToken, bound to a specific deployed contract.
+func NewWemixTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*WemixTokenTransactor, error) {
+	contract, err := bindWemixToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenTransactor{contract: contract}, nil
+}
+
+// NewWemixTokenFilterer creates a new log filterer instance of WemixToken, bound to a specific deployed contract.
+func NewWemixTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*WemixTokenFilterer, error) {
+	contract, err := bindWemixToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenFilterer{contract: contract}, nil
+}
+
+// bindWemixToken binds a generic wrapper to an already deployed contract.
+func bindWemixToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(WemixTokenABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_WemixToken *WemixTokenRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _WemixToken.Contract.WemixTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_WemixToken *WemixTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _WemixToken.Contract.WemixTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_WemixToken *WemixTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _WemixToken.Contract.WemixTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_WemixToken *WemixTokenCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _WemixToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_WemixToken *WemixTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _WemixToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_WemixToken *WemixTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _WemixToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// AllPartners is a free data retrieval call binding the contract method 0xa16f9151.
+//
+// Solidity: function allPartners(uint256 ) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenCaller) AllPartners(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	ret := new(struct {
+		Serial                 *big.Int
+		Partner                common.Address
+		Payer                  common.Address
+		BlockStaking           *big.Int
+		BlockWaitingWithdrawal *big.Int
+		BalanceStaking         *big.Int
+	})
+	out := ret
+	err := _WemixToken.contract.Call(opts, out, "allPartners", arg0)
+	return *ret, err
+}
+
+// AllPartners is a free data retrieval call binding the contract method 0xa16f9151.
+//
+// Solidity: function allPartners(uint256 ) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenSession) AllPartners(arg0 *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	return _WemixToken.Contract.AllPartners(&_WemixToken.CallOpts, arg0)
+}
+
+// AllPartners is a free data retrieval call binding the contract method 0xa16f9151.
+//
+// Solidity: function allPartners(uint256 ) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenCallerSession) AllPartners(arg0 *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	return _WemixToken.Contract.AllPartners(&_WemixToken.CallOpts, arg0)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_WemixToken *WemixTokenCaller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "allowance", owner, spender)
+	return *ret0, err
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_WemixToken *WemixTokenSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _WemixToken.Contract.Allowance(&_WemixToken.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _WemixToken.Contract.Allowance(&_WemixToken.CallOpts, owner, spender)
+}
+
+// AllowedPartners is a free data retrieval call binding the contract method 0x1b5c643c.
+//
+// Solidity: function allowedPartners(address ) view returns(bool)
+func (_WemixToken *WemixTokenCaller) AllowedPartners(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "allowedPartners", arg0)
+	return *ret0, err
+}
+
+// AllowedPartners is a free data retrieval call binding the contract method 0x1b5c643c.
+//
+// Solidity: function allowedPartners(address ) view returns(bool)
+func (_WemixToken *WemixTokenSession) AllowedPartners(arg0 common.Address) (bool, error) {
+	return _WemixToken.Contract.AllowedPartners(&_WemixToken.CallOpts, arg0)
+}
+
+// AllowedPartners is a free data retrieval call binding the contract method 0x1b5c643c.
+//
+// Solidity: function allowedPartners(address ) view returns(bool)
+func (_WemixToken *WemixTokenCallerSession) AllowedPartners(arg0 common.Address) (bool, error) {
+	return _WemixToken.Contract.AllowedPartners(&_WemixToken.CallOpts, arg0)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_WemixToken *WemixTokenCaller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "balanceOf", account)
+	return *ret0, err
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_WemixToken *WemixTokenSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _WemixToken.Contract.BalanceOf(&_WemixToken.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _WemixToken.Contract.BalanceOf(&_WemixToken.CallOpts, account)
+}
+
+// BlockToMint is a free data retrieval call binding the contract method 0x3004b981.
+//
+// Solidity: function blockToMint() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) BlockToMint(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "blockToMint")
+	return *ret0, err
+}
+
+// BlockToMint is a free data retrieval call binding the contract method 0x3004b981.
+//
+// Solidity: function blockToMint() view returns(uint256)
+func (_WemixToken *WemixTokenSession) BlockToMint() (*big.Int, error) {
+	return _WemixToken.Contract.BlockToMint(&_WemixToken.CallOpts)
+}
+
+// BlockToMint is a free data retrieval call binding the contract method 0x3004b981.
+//
+// Solidity: function blockToMint() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) BlockToMint() (*big.Int, error) {
+	return _WemixToken.Contract.BlockToMint(&_WemixToken.CallOpts)
+}
+
+// BlockUnitForMint is a free data retrieval call binding the contract method 0xd8975f98.
+//
+// Solidity: function blockUnitForMint() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) BlockUnitForMint(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "blockUnitForMint")
+	return *ret0, err
+}
+
+// BlockUnitForMint is a free data retrieval call binding the contract method 0xd8975f98.
+//
+// Solidity: function blockUnitForMint() view returns(uint256)
+func (_WemixToken *WemixTokenSession) BlockUnitForMint() (*big.Int, error) {
+	return _WemixToken.Contract.BlockUnitForMint(&_WemixToken.CallOpts)
+}
+
+// BlockUnitForMint is a free data retrieval call binding the contract method 0xd8975f98.
+//
+// Solidity: function blockUnitForMint() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) BlockUnitForMint() (*big.Int, error) {
+	return _WemixToken.Contract.BlockUnitForMint(&_WemixToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_WemixToken *WemixTokenCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var (
+		ret0 = new(uint8)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "decimals")
+	return *ret0, err
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_WemixToken *WemixTokenSession) Decimals() (uint8, error) {
+	return _WemixToken.Contract.Decimals(&_WemixToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_WemixToken *WemixTokenCallerSession) Decimals() (uint8, error) {
+	return _WemixToken.Contract.Decimals(&_WemixToken.CallOpts)
+}
+
+// EcoFund is a free data retrieval call binding the contract method 0xde80c858.
+//
+// Solidity: function ecoFund() view returns(address)
+func (_WemixToken *WemixTokenCaller) EcoFund(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "ecoFund")
+	return *ret0, err
+}
+
+// EcoFund is a free data retrieval call binding the contract method 0xde80c858.
+//
+// Solidity: function ecoFund() view returns(address)
+func (_WemixToken *WemixTokenSession) EcoFund() (common.Address, error) {
+	return _WemixToken.Contract.EcoFund(&_WemixToken.CallOpts)
+}
+
+// EcoFund is a free data retrieval call binding the contract method 0xde80c858.
+//
+// Solidity: function ecoFund() view returns(address)
+func (_WemixToken *WemixTokenCallerSession) EcoFund() (common.Address, error) {
+	return _WemixToken.Contract.EcoFund(&_WemixToken.CallOpts)
+}
+
+// IsMintable is a free data retrieval call binding the contract method 0x46b45af7.
+//
+// Solidity: function isMintable() view returns(bool)
+func (_WemixToken *WemixTokenCaller) IsMintable(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "isMintable")
+	return *ret0, err
+}
+
+// IsMintable is a free data retrieval call binding the contract method 0x46b45af7.
+//
+// Solidity: function isMintable() view returns(bool)
+func (_WemixToken *WemixTokenSession) IsMintable() (bool, error) {
+	return _WemixToken.Contract.IsMintable(&_WemixToken.CallOpts)
+}
+
+// IsMintable is a free data retrieval call binding the contract method 0x46b45af7.
+//
+// Solidity: function isMintable() view returns(bool)
+func (_WemixToken *WemixTokenCallerSession) IsMintable() (bool, error) {
+	return _WemixToken.Contract.IsMintable(&_WemixToken.CallOpts)
+}
+
+// IsOwner is a free data retrieval call binding the contract method 0x8f32d59b.
+//
+// Solidity: function isOwner() view returns(bool)
+func (_WemixToken *WemixTokenCaller) IsOwner(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "isOwner")
+	return *ret0, err
+}
+
+// IsOwner is a free data retrieval call binding the contract method 0x8f32d59b.
+//
+// Solidity: function isOwner() view returns(bool)
+func (_WemixToken *WemixTokenSession) IsOwner() (bool, error) {
+	return _WemixToken.Contract.IsOwner(&_WemixToken.CallOpts)
+}
+
+// IsOwner is a free data retrieval call binding the contract method 0x8f32d59b.
+//
+// Solidity: function isOwner() view returns(bool)
+func (_WemixToken *WemixTokenCallerSession) IsOwner() (bool, error) {
+	return _WemixToken.Contract.IsOwner(&_WemixToken.CallOpts)
+}
+
+// MinBlockWaitingWithdrawal is a free data retrieval call binding the contract method 0x3f0052b4.
+//
+// Solidity: function minBlockWaitingWithdrawal() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) MinBlockWaitingWithdrawal(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "minBlockWaitingWithdrawal")
+	return *ret0, err
+}
+
+// MinBlockWaitingWithdrawal is a free data retrieval call binding the contract method 0x3f0052b4.
+//
+// Solidity: function minBlockWaitingWithdrawal() view returns(uint256)
+func (_WemixToken *WemixTokenSession) MinBlockWaitingWithdrawal() (*big.Int, error) {
+	return _WemixToken.Contract.MinBlockWaitingWithdrawal(&_WemixToken.CallOpts)
+}
+
+// MinBlockWaitingWithdrawal is a free data retrieval call binding the contract method 0x3f0052b4.
+//
+// Solidity: function minBlockWaitingWithdrawal() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) MinBlockWaitingWithdrawal() (*big.Int, error) {
+	return _WemixToken.Contract.MinBlockWaitingWithdrawal(&_WemixToken.CallOpts)
+}
+
+// MintToEcoFund is a free data retrieval call binding the contract method 0xd4aa712a.
+//
+// Solidity: function mintToEcoFund() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) MintToEcoFund(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "mintToEcoFund")
+	return *ret0, err
+}
+
+// MintToEcoFund is a free data retrieval call binding the contract method 0xd4aa712a.
+//
+// Solidity: function mintToEcoFund() view returns(uint256)
+func (_WemixToken *WemixTokenSession) MintToEcoFund() (*big.Int, error) {
+	return _WemixToken.Contract.MintToEcoFund(&_WemixToken.CallOpts)
+}
+
+// MintToEcoFund is a free data retrieval call binding the contract method 0xd4aa712a.
+//
+// Solidity: function mintToEcoFund() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) MintToEcoFund() (*big.Int, error) {
+	return _WemixToken.Contract.MintToEcoFund(&_WemixToken.CallOpts)
+}
+
+// MintToPartner is a free data retrieval call binding the contract method 0xc4a6766d.
+//
+// Solidity: function mintToPartner() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) MintToPartner(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "mintToPartner")
+	return *ret0, err
+}
+
+// MintToPartner is a free data retrieval call binding the contract method 0xc4a6766d.
+//
+// Solidity: function mintToPartner() view returns(uint256)
+func (_WemixToken *WemixTokenSession) MintToPartner() (*big.Int, error) {
+	return _WemixToken.Contract.MintToPartner(&_WemixToken.CallOpts)
+}
+
+// MintToPartner is a free data retrieval call binding the contract method 0xc4a6766d.
+//
+// Solidity: function mintToPartner() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) MintToPartner() (*big.Int, error) {
+	return _WemixToken.Contract.MintToPartner(&_WemixToken.CallOpts)
+}
+
+// MintToWemix is a free data retrieval call binding the contract method 0x03c7f7e0.
+//
+// Solidity: function mintToWemix() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) MintToWemix(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "mintToWemix")
+	return *ret0, err
+}
+
+// MintToWemix is a free data retrieval call binding the contract method 0x03c7f7e0.
+//
+// Solidity: function mintToWemix() view returns(uint256)
+func (_WemixToken *WemixTokenSession) MintToWemix() (*big.Int, error) {
+	return _WemixToken.Contract.MintToWemix(&_WemixToken.CallOpts)
+}
+
+// MintToWemix is a free data retrieval call binding the contract method 0x03c7f7e0.
+//
+// Solidity: function mintToWemix() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) MintToWemix() (*big.Int, error) {
+	return _WemixToken.Contract.MintToWemix(&_WemixToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_WemixToken *WemixTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+	var (
+		ret0 = new(string)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "name")
+	return *ret0, err
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_WemixToken *WemixTokenSession) Name() (string, error) {
+	return _WemixToken.Contract.Name(&_WemixToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_WemixToken *WemixTokenCallerSession) Name() (string, error) {
+	return _WemixToken.Contract.Name(&_WemixToken.CallOpts)
+}
+
+// NextPartnerToMint is a free data retrieval call binding the contract method 0x5f004bcc.
+//
+// Solidity: function nextPartnerToMint() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) NextPartnerToMint(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "nextPartnerToMint")
+	return *ret0, err
+}
+
+// NextPartnerToMint is a free data retrieval call binding the contract method 0x5f004bcc.
+//
+// Solidity: function nextPartnerToMint() view returns(uint256)
+func (_WemixToken *WemixTokenSession) NextPartnerToMint() (*big.Int, error) {
+	return _WemixToken.Contract.NextPartnerToMint(&_WemixToken.CallOpts)
+}
+
+// NextPartnerToMint is a free data retrieval call binding the contract method 0x5f004bcc.
+//
+// Solidity: function nextPartnerToMint() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) NextPartnerToMint() (*big.Int, error) {
+	return _WemixToken.Contract.NextPartnerToMint(&_WemixToken.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_WemixToken *WemixTokenCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "owner")
+	return *ret0, err
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_WemixToken *WemixTokenSession) Owner() (common.Address, error) {
+	return _WemixToken.Contract.Owner(&_WemixToken.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_WemixToken *WemixTokenCallerSession) Owner() (common.Address, error) {
+	return _WemixToken.Contract.Owner(&_WemixToken.CallOpts)
+}
+
+// PartnerByIndex is a free data retrieval call binding the contract method 0x524180ba.
+//
+// Solidity: function partnerByIndex(uint256 _index) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenCaller) PartnerByIndex(opts *bind.CallOpts, _index *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	ret := new(struct {
+		Serial                 *big.Int
+		Partner                common.Address
+		Payer                  common.Address
+		BlockStaking           *big.Int
+		BlockWaitingWithdrawal *big.Int
+		BalanceStaking         *big.Int
+	})
+	out := ret
+	err := _WemixToken.contract.Call(opts, out, "partnerByIndex", _index)
+	return *ret, err
+}
+
+// PartnerByIndex is a free data retrieval call binding the contract method 0x524180ba.
+//
+// Solidity: function partnerByIndex(uint256 _index) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenSession) PartnerByIndex(_index *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	return _WemixToken.Contract.PartnerByIndex(&_WemixToken.CallOpts, _index)
+}
+
+// PartnerByIndex is a free data retrieval call binding the contract method 0x524180ba.
+//
+// Solidity: function partnerByIndex(uint256 _index) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenCallerSession) PartnerByIndex(_index *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	return _WemixToken.Contract.PartnerByIndex(&_WemixToken.CallOpts, _index)
+}
+
+// PartnerBySerial is a free data retrieval call binding the contract method 0x762e05ae.
+//
+// Solidity: function partnerBySerial(uint256 _serial) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenCaller) PartnerBySerial(opts *bind.CallOpts, _serial *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	ret := new(struct {
+		Serial                 *big.Int
+		Partner                common.Address
+		Payer                  common.Address
+		BlockStaking           *big.Int
+		BlockWaitingWithdrawal *big.Int
+		BalanceStaking         *big.Int
+	})
+	out := ret
+	err := _WemixToken.contract.Call(opts, out, "partnerBySerial", _serial)
+	return *ret, err
+}
+
+// PartnerBySerial is a free data retrieval call binding the contract method 0x762e05ae.
+//
+// Solidity: function partnerBySerial(uint256 _serial) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenSession) PartnerBySerial(_serial *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	return _WemixToken.Contract.PartnerBySerial(&_WemixToken.CallOpts, _serial)
+}
+
+// PartnerBySerial is a free data retrieval call binding the contract method 0x762e05ae.
+//
+// Solidity: function partnerBySerial(uint256 _serial) view returns(uint256 serial, address partner, address payer, uint256 blockStaking, uint256 blockWaitingWithdrawal, uint256 balanceStaking)
+func (_WemixToken *WemixTokenCallerSession) PartnerBySerial(_serial *big.Int) (struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}, error) {
+	return _WemixToken.Contract.PartnerBySerial(&_WemixToken.CallOpts, _serial)
+}
+
+// PartnersNumber is a free data retrieval call binding the contract method 0x7b9c0fec.
+//
+// Solidity: function partnersNumber() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) PartnersNumber(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "partnersNumber")
+	return *ret0, err
+}
+
+// PartnersNumber is a free data retrieval call binding the contract method 0x7b9c0fec.
+//
+// Solidity: function partnersNumber() view returns(uint256)
+func (_WemixToken *WemixTokenSession) PartnersNumber() (*big.Int, error) {
+	return _WemixToken.Contract.PartnersNumber(&_WemixToken.CallOpts)
+}
+
+// PartnersNumber is a free data retrieval call binding the contract method 0x7b9c0fec.
+//
+// Solidity: function partnersNumber() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) PartnersNumber() (*big.Int, error) {
+	return _WemixToken.Contract.PartnersNumber(&_WemixToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_WemixToken *WemixTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var (
+		ret0 = new(string)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "symbol")
+	return *ret0, err
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_WemixToken *WemixTokenSession) Symbol() (string, error) {
+	return _WemixToken.Contract.Symbol(&_WemixToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_WemixToken *WemixTokenCallerSession) Symbol() (string, error) {
+	return _WemixToken.Contract.Symbol(&_WemixToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "totalSupply")
+	return *ret0, err
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_WemixToken *WemixTokenSession) TotalSupply() (*big.Int, error) {
+	return _WemixToken.Contract.TotalSupply(&_WemixToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _WemixToken.Contract.TotalSupply(&_WemixToken.CallOpts)
+}
+
+// UnitStaking is a free data retrieval call binding the contract method 0xf3b92eab.
+//
+// Solidity: function unitStaking() view returns(uint256)
+func (_WemixToken *WemixTokenCaller) UnitStaking(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "unitStaking")
+	return *ret0, err
+}
+
+// UnitStaking is a free data retrieval call binding the contract method 0xf3b92eab.
+//
+// Solidity: function unitStaking() view returns(uint256)
+func (_WemixToken *WemixTokenSession) UnitStaking() (*big.Int, error) {
+	return _WemixToken.Contract.UnitStaking(&_WemixToken.CallOpts)
+}
+
+// UnitStaking is a free data retrieval call binding the contract method 0xf3b92eab.
+//
+// Solidity: function unitStaking() view returns(uint256)
+func (_WemixToken *WemixTokenCallerSession) UnitStaking() (*big.Int, error) {
+	return _WemixToken.Contract.UnitStaking(&_WemixToken.CallOpts)
+}
+
+// Wemix is a free data retrieval call binding the contract method 0x88d826a3.
+//
+// Solidity: function wemix() view returns(address)
+func (_WemixToken *WemixTokenCaller) Wemix(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _WemixToken.contract.Call(opts, out, "wemix")
+	return *ret0, err
+}
+
+// Wemix is a free data retrieval call binding the contract method 0x88d826a3.
+//
+// Solidity: function wemix() view returns(address)
+func (_WemixToken *WemixTokenSession) Wemix() (common.Address, error) {
+	return _WemixToken.Contract.Wemix(&_WemixToken.CallOpts)
+}
+
+// Wemix is a free data retrieval call binding the contract method 0x88d826a3.
+//
+// Solidity: function wemix() view returns(address)
+func (_WemixToken *WemixTokenCallerSession) Wemix() (common.Address, error) {
+	return _WemixToken.Contract.Wemix(&_WemixToken.CallOpts)
+}
+
+// AddAllowedPartner is a paid mutator transaction binding the contract method 0xecf63a4e.
+//
+// Solidity: function addAllowedPartner(address _account) returns()
+func (_WemixToken *WemixTokenTransactor) AddAllowedPartner(opts *bind.TransactOpts, _account common.Address) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "addAllowedPartner", _account)
+}
+
+// AddAllowedPartner is a paid mutator transaction binding the contract method 0xecf63a4e.
+//
+// Solidity: function addAllowedPartner(address _account) returns()
+func (_WemixToken *WemixTokenSession) AddAllowedPartner(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.AddAllowedPartner(&_WemixToken.TransactOpts, _account)
+}
+
+// AddAllowedPartner is a paid mutator transaction binding the contract method 0xecf63a4e.
+//
+// Solidity: function addAllowedPartner(address _account) returns()
+func (_WemixToken *WemixTokenTransactorSession) AddAllowedPartner(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.AddAllowedPartner(&_WemixToken.TransactOpts, _account)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Approve(&_WemixToken.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Approve(&_WemixToken.TransactOpts, spender, amount)
+}
+
+// ChangeBlockUnitForMint is a paid mutator transaction binding the contract method 0x9243a735.
+//
+// Solidity: function change_blockUnitForMint(uint256 _block) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeBlockUnitForMint(opts *bind.TransactOpts, _block *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_blockUnitForMint", _block)
+}
+
+// ChangeBlockUnitForMint is a paid mutator transaction binding the contract method 0x9243a735.
+//
+// Solidity: function change_blockUnitForMint(uint256 _block) returns()
+func (_WemixToken *WemixTokenSession) ChangeBlockUnitForMint(_block *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeBlockUnitForMint(&_WemixToken.TransactOpts, _block)
+}
+
+// ChangeBlockUnitForMint is a paid mutator transaction binding the contract method 0x9243a735.
+//
+// Solidity: function change_blockUnitForMint(uint256 _block) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeBlockUnitForMint(_block *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeBlockUnitForMint(&_WemixToken.TransactOpts, _block)
+}
+
+// ChangeEcoFund is a paid mutator transaction binding the contract method 0x62d0fa4a.
+//
+// Solidity: function change_ecoFund(address _account) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeEcoFund(opts *bind.TransactOpts, _account common.Address) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_ecoFund", _account)
+}
+
+// ChangeEcoFund is a paid mutator transaction binding the contract method 0x62d0fa4a.
+//
+// Solidity: function change_ecoFund(address _account) returns()
+func (_WemixToken *WemixTokenSession) ChangeEcoFund(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeEcoFund(&_WemixToken.TransactOpts, _account)
+}
+
+// ChangeEcoFund is a paid mutator transaction binding the contract method 0x62d0fa4a.
+//
+// Solidity: function change_ecoFund(address _account) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeEcoFund(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeEcoFund(&_WemixToken.TransactOpts, _account)
+}
+
+// ChangeMinBlockWaitingWithdrawal is a paid mutator transaction binding the contract method 0xa787809a.
+//
+// Solidity: function change_minBlockWaitingWithdrawal(uint256 _block) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeMinBlockWaitingWithdrawal(opts *bind.TransactOpts, _block *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_minBlockWaitingWithdrawal", _block)
+}
+
+// ChangeMinBlockWaitingWithdrawal is a paid mutator transaction binding the contract method 0xa787809a.
+//
+// Solidity: function change_minBlockWaitingWithdrawal(uint256 _block) returns()
+func (_WemixToken *WemixTokenSession) ChangeMinBlockWaitingWithdrawal(_block *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMinBlockWaitingWithdrawal(&_WemixToken.TransactOpts, _block)
+}
+
+// ChangeMinBlockWaitingWithdrawal is a paid mutator transaction binding the contract method 0xa787809a.
+//
+// Solidity: function change_minBlockWaitingWithdrawal(uint256 _block) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeMinBlockWaitingWithdrawal(_block *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMinBlockWaitingWithdrawal(&_WemixToken.TransactOpts, _block)
+}
+
+// ChangeMintToEcoFund is a paid mutator transaction binding the contract method 0x184ec7b9.
+//
+// Solidity: function change_mintToEcoFund(uint256 _value) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeMintToEcoFund(opts *bind.TransactOpts, _value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_mintToEcoFund", _value)
+}
+
+// ChangeMintToEcoFund is a paid mutator transaction binding the contract method 0x184ec7b9.
+//
+// Solidity: function change_mintToEcoFund(uint256 _value) returns()
+func (_WemixToken *WemixTokenSession) ChangeMintToEcoFund(_value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMintToEcoFund(&_WemixToken.TransactOpts, _value)
+}
+
+// ChangeMintToEcoFund is a paid mutator transaction binding the contract method 0x184ec7b9.
+//
+// Solidity: function change_mintToEcoFund(uint256 _value) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeMintToEcoFund(_value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMintToEcoFund(&_WemixToken.TransactOpts, _value)
+}
+
+// ChangeMintToPartner is a paid mutator transaction binding the contract method 0x3a551b24.
+//
+// Solidity: function change_mintToPartner(uint256 _value) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeMintToPartner(opts *bind.TransactOpts, _value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_mintToPartner", _value)
+}
+
+// ChangeMintToPartner is a paid mutator transaction binding the contract method 0x3a551b24.
+//
+// Solidity: function change_mintToPartner(uint256 _value) returns()
+func (_WemixToken *WemixTokenSession) ChangeMintToPartner(_value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMintToPartner(&_WemixToken.TransactOpts, _value)
+}
+
+// ChangeMintToPartner is a paid mutator transaction binding the contract method 0x3a551b24.
+//
+// Solidity: function change_mintToPartner(uint256 _value) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeMintToPartner(_value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMintToPartner(&_WemixToken.TransactOpts, _value)
+}
+
+// ChangeMintToWemix is a paid mutator transaction binding the contract method 0x107bb9cf.
+//
+// Solidity: function change_mintToWemix(uint256 _value) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeMintToWemix(opts *bind.TransactOpts, _value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_mintToWemix", _value)
+}
+
+// ChangeMintToWemix is a paid mutator transaction binding the contract method 0x107bb9cf.
+//
+// Solidity: function change_mintToWemix(uint256 _value) returns()
+func (_WemixToken *WemixTokenSession) ChangeMintToWemix(_value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMintToWemix(&_WemixToken.TransactOpts, _value)
+}
+
+// ChangeMintToWemix is a paid mutator transaction binding the contract method 0x107bb9cf.
+//
+// Solidity: function change_mintToWemix(uint256 _value) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeMintToWemix(_value *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeMintToWemix(&_WemixToken.TransactOpts, _value)
+}
+
+// ChangeUnitStaking is a paid mutator transaction binding the contract method 0xbbf5d7f7.
+//
+// Solidity: function change_unitStaking(uint256 _unit) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeUnitStaking(opts *bind.TransactOpts, _unit *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_unitStaking", _unit)
+}
+
+// ChangeUnitStaking is a paid mutator transaction binding the contract method 0xbbf5d7f7.
+//
+// Solidity: function change_unitStaking(uint256 _unit) returns()
+func (_WemixToken *WemixTokenSession) ChangeUnitStaking(_unit *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeUnitStaking(&_WemixToken.TransactOpts, _unit)
+}
+
+// ChangeUnitStaking is a paid mutator transaction binding the contract method 0xbbf5d7f7.
+//
+// Solidity: function change_unitStaking(uint256 _unit) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeUnitStaking(_unit *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeUnitStaking(&_WemixToken.TransactOpts, _unit)
+}
+
+// ChangeWemix is a paid mutator transaction binding the contract method 0x44939ca5.
+//
+// Solidity: function change_wemix(address _account) returns()
+func (_WemixToken *WemixTokenTransactor) ChangeWemix(opts *bind.TransactOpts, _account common.Address) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "change_wemix", _account)
+}
+
+// ChangeWemix is a paid mutator transaction binding the contract method 0x44939ca5.
+//
+// Solidity: function change_wemix(address _account) returns()
+func (_WemixToken *WemixTokenSession) ChangeWemix(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeWemix(&_WemixToken.TransactOpts, _account)
+}
+
+// ChangeWemix is a paid mutator transaction binding the contract method 0x44939ca5.
+//
+// Solidity: function change_wemix(address _account) returns()
+func (_WemixToken *WemixTokenTransactorSession) ChangeWemix(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.ChangeWemix(&_WemixToken.TransactOpts, _account)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_WemixToken *WemixTokenTransactor) DecreaseAllowance(opts *bind.TransactOpts, spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "decreaseAllowance", spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_WemixToken *WemixTokenSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.DecreaseAllowance(&_WemixToken.TransactOpts, spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_WemixToken *WemixTokenTransactorSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.DecreaseAllowance(&_WemixToken.TransactOpts, spender, subtractedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_WemixToken *WemixTokenTransactor) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "increaseAllowance", spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_WemixToken *WemixTokenSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.IncreaseAllowance(&_WemixToken.TransactOpts, spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_WemixToken *WemixTokenTransactorSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.IncreaseAllowance(&_WemixToken.TransactOpts, spender, addedValue)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x1249c58b.
+//
+// Solidity: function mint() returns()
+func (_WemixToken *WemixTokenTransactor) Mint(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "mint")
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x1249c58b.
+//
+// Solidity: function mint() returns()
+func (_WemixToken *WemixTokenSession) Mint() (*types.Transaction, error) {
+	return _WemixToken.Contract.Mint(&_WemixToken.TransactOpts)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x1249c58b.
+//
+// Solidity: function mint() returns()
+func (_WemixToken *WemixTokenTransactorSession) Mint() (*types.Transaction, error) {
+	return _WemixToken.Contract.Mint(&_WemixToken.TransactOpts)
+}
+
+// RemoveAllowedPartner is a paid mutator transaction binding the contract method 0xf5276b34.
+//
+// Solidity: function removeAllowedPartner(address _account) returns()
+func (_WemixToken *WemixTokenTransactor) RemoveAllowedPartner(opts *bind.TransactOpts, _account common.Address) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "removeAllowedPartner", _account)
+}
+
+// RemoveAllowedPartner is a paid mutator transaction binding the contract method 0xf5276b34.
+//
+// Solidity: function removeAllowedPartner(address _account) returns()
+func (_WemixToken *WemixTokenSession) RemoveAllowedPartner(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.RemoveAllowedPartner(&_WemixToken.TransactOpts, _account)
+}
+
+// RemoveAllowedPartner is a paid mutator transaction binding the contract method 0xf5276b34.
+//
+// Solidity: function removeAllowedPartner(address _account) returns()
+func (_WemixToken *WemixTokenTransactorSession) RemoveAllowedPartner(_account common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.RemoveAllowedPartner(&_WemixToken.TransactOpts, _account)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_WemixToken *WemixTokenTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_WemixToken *WemixTokenSession) RenounceOwnership() (*types.Transaction, error) {
+	return _WemixToken.Contract.RenounceOwnership(&_WemixToken.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_WemixToken *WemixTokenTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _WemixToken.Contract.RenounceOwnership(&_WemixToken.TransactOpts)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 _withdrawalWaitingMinBlock) returns()
+func (_WemixToken *WemixTokenTransactor) Stake(opts *bind.TransactOpts, _withdrawalWaitingMinBlock *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "stake", _withdrawalWaitingMinBlock)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 _withdrawalWaitingMinBlock) returns()
+func (_WemixToken *WemixTokenSession) Stake(_withdrawalWaitingMinBlock *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Stake(&_WemixToken.TransactOpts, _withdrawalWaitingMinBlock)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 _withdrawalWaitingMinBlock) returns()
+func (_WemixToken *WemixTokenTransactorSession) Stake(_withdrawalWaitingMinBlock *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Stake(&_WemixToken.TransactOpts, _withdrawalWaitingMinBlock)
+}
+
+// StakeDelegated is a paid mutator transaction binding the contract method 0xade9afa8.
+//
+// Solidity: function stakeDelegated(address _partner, uint256 _withdrawalWaitingMinBlock) returns()
+func (_WemixToken *WemixTokenTransactor) StakeDelegated(opts *bind.TransactOpts, _partner common.Address, _withdrawalWaitingMinBlock *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "stakeDelegated", _partner, _withdrawalWaitingMinBlock)
+}
+
+// StakeDelegated is a paid mutator transaction binding the contract method 0xade9afa8.
+//
+// Solidity: function stakeDelegated(address _partner, uint256 _withdrawalWaitingMinBlock) returns()
+func (_WemixToken *WemixTokenSession) StakeDelegated(_partner common.Address, _withdrawalWaitingMinBlock *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.StakeDelegated(&_WemixToken.TransactOpts, _partner, _withdrawalWaitingMinBlock)
+}
+
+// StakeDelegated is a paid mutator transaction binding the contract method 0xade9afa8.
+//
+// Solidity: function stakeDelegated(address _partner, uint256 _withdrawalWaitingMinBlock) returns()
+func (_WemixToken *WemixTokenTransactorSession) StakeDelegated(_partner common.Address, _withdrawalWaitingMinBlock *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.StakeDelegated(&_WemixToken.TransactOpts, _partner, _withdrawalWaitingMinBlock)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address recipient, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenTransactor) Transfer(opts *bind.TransactOpts, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "transfer", recipient, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address recipient, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenSession) Transfer(recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Transfer(&_WemixToken.TransactOpts, recipient, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address recipient, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenTransactorSession) Transfer(recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Transfer(&_WemixToken.TransactOpts, recipient, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address sender, address recipient, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenTransactor) TransferFrom(opts *bind.TransactOpts, sender common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "transferFrom", sender, recipient, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address sender, address recipient, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenSession) TransferFrom(sender common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.TransferFrom(&_WemixToken.TransactOpts, sender, recipient, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address sender, address recipient, uint256 amount) returns(bool)
+func (_WemixToken *WemixTokenTransactorSession) TransferFrom(sender common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.TransferFrom(&_WemixToken.TransactOpts, sender, recipient, amount)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_WemixToken *WemixTokenTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_WemixToken *WemixTokenSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.TransferOwnership(&_WemixToken.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_WemixToken *WemixTokenTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _WemixToken.Contract.TransferOwnership(&_WemixToken.TransactOpts, newOwner)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 _serial) returns()
+func (_WemixToken *WemixTokenTransactor) Withdraw(opts *bind.TransactOpts, _serial *big.Int) (*types.Transaction, error) {
+	return _WemixToken.contract.Transact(opts, "withdraw", _serial)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 _serial) returns()
+func (_WemixToken *WemixTokenSession) Withdraw(_serial *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Withdraw(&_WemixToken.TransactOpts, _serial)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 _serial) returns()
+func (_WemixToken *WemixTokenTransactorSession) Withdraw(_serial *big.Int) (*types.Transaction, error) {
+	return _WemixToken.Contract.Withdraw(&_WemixToken.TransactOpts, _serial)
+}
+
+// WemixTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the WemixToken contract.
+type WemixTokenApprovalIterator struct {
+	Event *WemixTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *WemixTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(WemixTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(WemixTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *WemixTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *WemixTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WemixTokenApproval represents a Approval event raised by the WemixToken contract.
+type WemixTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_WemixToken *WemixTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*WemixTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenApprovalIterator{contract: _WemixToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_WemixToken *WemixTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *WemixTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(WemixTokenApproval)
+				if err := _WemixToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_WemixToken *WemixTokenFilterer) ParseApproval(log types.Log) (*WemixTokenApproval, error) {
+	event := new(WemixTokenApproval)
+	if err := _WemixToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// WemixTokenOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the WemixToken contract.
+type WemixTokenOwnershipTransferredIterator struct {
+	Event *WemixTokenOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *WemixTokenOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(WemixTokenOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(WemixTokenOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *WemixTokenOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *WemixTokenOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WemixTokenOwnershipTransferred represents a OwnershipTransferred event raised by the WemixToken contract.
+type WemixTokenOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_WemixToken *WemixTokenFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*WemixTokenOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenOwnershipTransferredIterator{contract: _WemixToken.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_WemixToken *WemixTokenFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *WemixTokenOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(WemixTokenOwnershipTransferred)
+				if err := _WemixToken.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_WemixToken *WemixTokenFilterer) ParseOwnershipTransferred(log types.Log) (*WemixTokenOwnershipTransferred, error) {
+	event := new(WemixTokenOwnershipTransferred)
+	if err := _WemixToken.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// WemixTokenStakedIterator is returned from FilterStaked and is used to iterate over the raw logs and unpacked data for Staked events raised by the WemixToken contract.
+type WemixTokenStakedIterator struct {
+	Event *WemixTokenStaked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *WemixTokenStakedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(WemixTokenStaked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(WemixTokenStaked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *WemixTokenStakedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *WemixTokenStakedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WemixTokenStaked represents a Staked event raised by the WemixToken contract.
+type WemixTokenStaked struct {
+	Partner common.Address
+	Payer   common.Address
+	Serial  *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterStaked is a free log retrieval operation binding the contract event 0x5dac0c1b1112564a045ba943c9d50270893e8e826c49be8e7073adc713ab7bd7.
+//
+// Solidity: event Staked(address indexed partner, address indexed payer, uint256 indexed serial)
+func (_WemixToken *WemixTokenFilterer) FilterStaked(opts *bind.FilterOpts, partner []common.Address, payer []common.Address, serial []*big.Int) (*WemixTokenStakedIterator, error) {
+
+	var partnerRule []interface{}
+	for _, partnerItem := range partner {
+		partnerRule = append(partnerRule, partnerItem)
+	}
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var serialRule []interface{}
+	for _, serialItem := range serial {
+		serialRule = append(serialRule, serialItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.FilterLogs(opts, "Staked", partnerRule, payerRule, serialRule)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenStakedIterator{contract: _WemixToken.contract, event: "Staked", logs: logs, sub: sub}, nil
+}
+
+// WatchStaked is a free log subscription operation binding the contract event 0x5dac0c1b1112564a045ba943c9d50270893e8e826c49be8e7073adc713ab7bd7.
+//
+// Solidity: event Staked(address indexed partner, address indexed payer, uint256 indexed serial)
+func (_WemixToken *WemixTokenFilterer) WatchStaked(opts *bind.WatchOpts, sink chan<- *WemixTokenStaked, partner []common.Address, payer []common.Address, serial []*big.Int) (event.Subscription, error) {
+
+	var partnerRule []interface{}
+	for _, partnerItem := range partner {
+		partnerRule = append(partnerRule, partnerItem)
+	}
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var serialRule []interface{}
+	for _, serialItem := range serial {
+		serialRule = append(serialRule, serialItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.WatchLogs(opts, "Staked", partnerRule, payerRule, serialRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(WemixTokenStaked)
+				if err := _WemixToken.contract.UnpackLog(event, "Staked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStaked is a log parse operation binding the contract event 0x5dac0c1b1112564a045ba943c9d50270893e8e826c49be8e7073adc713ab7bd7.
+//
+// Solidity: event Staked(address indexed partner, address indexed payer, uint256 indexed serial)
+func (_WemixToken *WemixTokenFilterer) ParseStaked(log types.Log) (*WemixTokenStaked, error) {
+	event := new(WemixTokenStaked)
+	if err := _WemixToken.contract.UnpackLog(event, "Staked", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// WemixTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the WemixToken contract.
+type WemixTokenTransferIterator struct {
+	Event *WemixTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *WemixTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(WemixTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(WemixTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *WemixTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *WemixTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WemixTokenTransfer represents a Transfer event raised by the WemixToken contract.
+type WemixTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_WemixToken *WemixTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*WemixTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenTransferIterator{contract: _WemixToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_WemixToken *WemixTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *WemixTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(WemixTokenTransfer)
+				if err := _WemixToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_WemixToken *WemixTokenFilterer) ParseTransfer(log types.Log) (*WemixTokenTransfer, error) {
+	event := new(WemixTokenTransfer)
+	if err := _WemixToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// WemixTokenWithdrawalIterator is returned from FilterWithdrawal and is used to iterate over the raw logs and unpacked data for Withdrawal events raised by the WemixToken contract.
+type WemixTokenWithdrawalIterator struct {
+	Event *WemixTokenWithdrawal // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *WemixTokenWithdrawalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(WemixTokenWithdrawal)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(WemixTokenWithdrawal)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *WemixTokenWithdrawalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *WemixTokenWithdrawalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WemixTokenWithdrawal represents a Withdrawal event raised by the WemixToken contract.
+type WemixTokenWithdrawal struct {
+	Partner common.Address
+	Payer   common.Address
+	Serial  *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawal is a free log retrieval operation binding the contract event 0x2717ead6b9200dd235aad468c9809ea400fe33ac69b5bfaa6d3e90fc922b6398.
+//
+// Solidity: event Withdrawal(address indexed partner, address indexed payer, uint256 indexed serial)
+func (_WemixToken *WemixTokenFilterer) FilterWithdrawal(opts *bind.FilterOpts, partner []common.Address, payer []common.Address, serial []*big.Int) (*WemixTokenWithdrawalIterator, error) {
+
+	var partnerRule []interface{}
+	for _, partnerItem := range partner {
+		partnerRule = append(partnerRule, partnerItem)
+	}
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var serialRule []interface{}
+	for _, serialItem := range serial {
+		serialRule = append(serialRule, serialItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.FilterLogs(opts, "Withdrawal", partnerRule, payerRule, serialRule)
+	if err != nil {
+		return nil, err
+	}
+	return &WemixTokenWithdrawalIterator{contract: _WemixToken.contract, event: "Withdrawal", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawal is a free log subscription operation binding the contract event 0x2717ead6b9200dd235aad468c9809ea400fe33ac69b5bfaa6d3e90fc922b6398.
+//
+// Solidity: event Withdrawal(address indexed partner, address indexed payer, uint256 indexed serial)
+func (_WemixToken *WemixTokenFilterer) WatchWithdrawal(opts *bind.WatchOpts, sink chan<- *WemixTokenWithdrawal, partner []common.Address, payer []common.Address, serial []*big.Int) (event.Subscription, error) {
+
+	var partnerRule []interface{}
+	for _, partnerItem := range partner {
+		partnerRule = append(partnerRule, partnerItem)
+	}
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var serialRule []interface{}
+	for _, serialItem := range serial {
+		serialRule = append(serialRule, serialItem)
+	}
+
+	logs, sub, err := _WemixToken.contract.WatchLogs(opts, "Withdrawal", partnerRule, payerRule, serialRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(WemixTokenWithdrawal)
+				if err := _WemixToken.contract.UnpackLog(event, "Withdrawal", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawal is a log parse operation binding the contract event 0x2717ead6b9200dd235aad468c9809ea400fe33ac69b5bfaa6d3e90fc922b6398.
+//
+// Solidity: event Withdrawal(address indexed partner, address indexed payer, uint256 indexed serial)
+func (_WemixToken *WemixTokenFilterer) ParseWithdrawal(log types.Log) (*WemixTokenWithdrawal, error) {
+	event := new(WemixTokenWithdrawal)
+	if err := _WemixToken.contract.UnpackLog(event, "Withdrawal", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
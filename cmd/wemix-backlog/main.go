@@ -0,0 +1,181 @@
+//Command wemix-backlog reports mint() calls outstanding on WemixToken, and tokens each recipient is owed by them.
+//
+//	wemix-backlog -rpc http://localhost:8545 -address 0x5096...
+//	wemix-backlog -simulate -partners 5 -behind 6000
+//	wemix-backlog -rpc http://localhost:8545 -address 0x5096... -simulate -json
+//
+//With -rpc, the backlog is read from a node at the latest block, or at -block.
+//With -simulate, the contract is deployed on a simulated backend with -partners partners and -behind blocks since blockToMint,
+//and the catch-up is sent through the EVM to report gas used by every call and tokens minted.
+//With both, the simulation takes the number of partners and blocks behind of the node, with the default parameters of the contract.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/model"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func main() {
+	var (
+		rpcURL   = flag.String("rpc", "", "JSON-RPC url of a node")
+		address  = flag.String("address", "", "WemixToken address on the node")
+		block    = flag.Int64("block", 0, "block to read at, the latest block if 0")
+		simulate = flag.Bool("simulate", false, "send the catch-up through the EVM on a simulated backend")
+		partners = flag.Int("partners", 3, "partners of the simulation")
+		behind   = flag.Int64("behind", 600, "blocks since blockToMint of the simulation")
+		sol      = flag.String("sol", "contracts/WemixToken.sol", "solidity file of WemixToken, for -simulate")
+		jsonFlag = flag.Bool("json", false, "print JSON")
+	)
+	flag.Parse()
+
+	if err := run(*rpcURL, *address, *block, *simulate, *partners, *behind, *sol, *jsonFlag); err != nil {
+		fmt.Fprintln(os.Stderr, "wemix-backlog:", err)
+		os.Exit(1)
+	}
+}
+
+func run(rpcURL, address string, block int64, simulate bool, partners int, behind int64, sol string, jsonOut bool) error {
+	if rpcURL == "" && simulate == false {
+		return errors.New("-rpc or -simulate is needed")
+	}
+
+	if rpcURL != "" {
+		if common.IsHexAddress(address) == false {
+			return fmt.Errorf("bad -address %q", address)
+		}
+		client, err := wemix.Dial(rpcURL, common.HexToAddress(address), nil)
+		if err != nil {
+			return err
+		}
+		at := (*big.Int)(nil)
+		if block > 0 {
+			at = big.NewInt(block)
+		}
+		backlog, err := client.MintBacklog(context.Background(), at)
+		if err != nil {
+			return err
+		}
+		if simulate == false {
+			return output(backlog, jsonOut, func() error { return backlog.WriteTable(os.Stdout) })
+		}
+		partners = 0
+		for _, o := range backlog.Owed {
+			if o.Kind == "partner" {
+				partners++
+			}
+		}
+		behind = backlog.Behind.Int64()
+	}
+
+	catchUp, err := simulateCatchUp(sol, partners, behind)
+	if err != nil {
+		return err
+	}
+	if err := checkMinted(catchUp); err != nil {
+		return err
+	}
+	return output(catchUp, jsonOut, func() error { return writeCatchUp(catchUp) })
+}
+
+//output prints v as JSON, or by table.
+func output(v interface{}, jsonOut bool, table func() error) error {
+	if jsonOut == false {
+		return table()
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+//simulateCatchUp deploys the contract, stakes the partners delegated by the owner, lets blocks pass
+//until the next block is behind blocks after blockToMint, and sends the catch-up.
+func simulateCatchUp(sol string, partners int, behind int64) (*wemix.CatchUp, error) {
+	contract, err := backend.NewContract(sol, "WemixToken")
+	if err != nil {
+		return nil, err
+	}
+	contract.GasReport, contract.Coverage = nil, nil
+
+	ecoFundKey, _ := crypto.GenerateKey()
+	wemixKey, _ := crypto.GenerateKey()
+	if err := contract.Deploy(
+		crypto.PubkeyToAddress(ecoFundKey.PublicKey),
+		crypto.PubkeyToAddress(wemixKey.PublicKey),
+	); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	owner, err := wemix.NewClient(contract.Address, contract.Backend, contract.OwnerKey)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < partners; i++ {
+		partner := model.LabelAddress(fmt.Sprint(i + 1))
+		if _, err := owner.AllowPartner(ctx, partner); err != nil {
+			return nil, err
+		}
+		if _, err := owner.StakeFor(ctx, partner, new(big.Int)); err != nil {
+			return nil, err
+		}
+	}
+
+	blockToMint, err := owner.Token.BlockToMint(nil)
+	if err != nil {
+		return nil, err
+	}
+	last := new(big.Int).Add(blockToMint, big.NewInt(behind-1))
+	for contract.Backend.Blockchain().CurrentBlock().Number().Cmp(last) < 0 {
+		contract.Backend.Commit()
+	}
+	return wemix.SimulateCatchUp(contract)
+}
+
+func writeCatchUp(c *wemix.CatchUp) error {
+	if err := c.Backlog.WriteTable(os.Stdout); err != nil {
+		return err
+	}
+	if len(c.Gas) == 0 {
+		return nil
+	}
+	lo, hi := c.Gas[0], c.Gas[0]
+	for _, gas := range c.Gas {
+		if gas < lo {
+			lo = gas
+		}
+		if gas > hi {
+			hi = gas
+		}
+	}
+	fmt.Printf("catch-up: %d calls, %d gas in total, %d to %d gas per call\n", len(c.Gas), c.GasTotal, lo, hi)
+	return nil
+}
+
+//checkMinted compares tokens minted by the catch-up with tokens owed, which the model predicted.
+func checkMinted(c *wemix.CatchUp) error {
+	owed := c.Backlog.ByAddress()
+	for _, o := range c.Backlog.Owed {
+		minted := c.Minted[o.To]
+		if minted == nil {
+			minted = new(big.Int)
+		}
+		if minted.Cmp(owed[o.To]) != 0 {
+			return fmt.Errorf("%s minted %v, owed %v", o.To.Hex(), minted, owed[o.To])
+		}
+	}
+	return nil
+}
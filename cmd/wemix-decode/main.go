@@ -0,0 +1,119 @@
+//Command wemix-decode decodes WemixToken calldata or raw signed transactions given as hex.
+//
+//	wemix-decode 0xa9059cbb...
+//	wemix-decode -tx 0xf8a9...
+//	echo 0xa9059cbb... | wemix-decode -json
+//
+//Inputs are read from arguments, or one per line from stdin without arguments.
+//The exit status is 2 when a selector is not in the ABI, and 1 on other errors.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/wemade-tree/wemix-token/binding"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func main() {
+	var (
+		tx       = flag.Bool("tx", false, "inputs are raw signed transactions instead of calldata")
+		abiFile  = flag.String("abi", "", "abi file, the WemixToken abi of the binding by default")
+		jsonFlag = flag.Bool("json", false, "print JSON")
+	)
+	flag.Parse()
+
+	parsed, err := loadABI(*abiFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wemix-decode:", err)
+		os.Exit(1)
+	}
+
+	inputs := flag.Args()
+	if len(inputs) == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				inputs = append(inputs, line)
+			}
+		}
+	}
+
+	status := 0
+	for _, input := range inputs {
+		out, unknown, err := decode(&parsed, input, *tx, *jsonFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "wemix-decode: %s: %v\n", abbreviate(input), err)
+			status = 1
+			continue
+		}
+		fmt.Println(out)
+		if unknown && status == 0 {
+			status = 2
+		}
+	}
+	os.Exit(status)
+}
+
+func loadABI(file string) (abi.ABI, error) {
+	text := binding.WemixTokenABI
+	if file != "" {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return abi.ABI{}, err
+		}
+		text = string(b)
+	}
+	return abi.JSON(strings.NewReader(text))
+}
+
+//decode returns the text to print, and whether a selector is not in the ABI.
+func decode(a *abi.ABI, input string, tx, jsonOut bool) (string, bool, error) {
+	if strings.HasPrefix(input, "0x") == false && strings.HasPrefix(input, "0X") == false {
+		input = "0x" + input
+	}
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return "", false, err
+	}
+
+	var (
+		result  fmt.Stringer
+		unknown bool
+	)
+	if tx {
+		t, err := wemix.DecodeTransaction(a, data)
+		if err != nil {
+			return "", false, err
+		}
+		result, unknown = t, t.Call != nil && t.Call.Unknown
+	} else {
+		c, err := wemix.DecodeCalldata(a, data)
+		if err != nil {
+			return "", false, err
+		}
+		result, unknown = c, c.Unknown
+	}
+
+	if jsonOut {
+		b, err := json.Marshal(result)
+		return string(b), unknown, err
+	}
+	return result.String(), unknown, nil
+}
+
+func abbreviate(s string) string {
+	if len(s) > 20 {
+		return s[:20] + "..."
+	}
+	return s
+}
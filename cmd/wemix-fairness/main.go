@@ -0,0 +1,93 @@
+//Command wemix-fairness runs a schedule of stakes, withdrawals and mints on WemixToken,
+//and reports rewards of each partner against a perfectly fair split of the tokens minted to partners.
+//
+//	wemix-fairness schedule.txt
+//	printf 'stake A\nstake B\nstake C\nmint 2\nwithdraw A\nmint 3\n' | wemix-fairness
+//	wemix-fairness -model -json schedule.txt
+//
+//A schedule has a step per line, "stake <label>", "withdraw <label>" or "mint [n]", and text after # is a comment.
+//The schedule is read from the file argument, or from stdin without an argument.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/model"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func main() {
+	var (
+		sol       = flag.String("sol", "contracts/WemixToken.sol", "solidity file of WemixToken")
+		modelFlag = flag.Bool("model", false, "run the Go model instead of the contract, without solc")
+		jsonFlag  = flag.Bool("json", false, "print JSON")
+	)
+	flag.Parse()
+
+	if err := run(*sol, *modelFlag, *jsonFlag, flag.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, "wemix-fairness:", err)
+		os.Exit(1)
+	}
+}
+
+func run(sol string, onModel, jsonOut bool, file string) error {
+	in := io.Reader(os.Stdin)
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	steps, err := model.ParseSchedule(in)
+	if err != nil {
+		return err
+	}
+
+	var r *model.ScheduleRun
+	if onModel {
+		r, err = model.RunSchedule(steps)
+	} else {
+		r, err = runContract(sol, steps)
+	}
+	if err != nil {
+		return err
+	}
+
+	report := r.Fairness()
+	if jsonOut {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	return report.WriteTable(os.Stdout)
+}
+
+//runContract deploys the contract on the simulated backend and runs the schedule on it.
+func runContract(sol string, steps []model.ScheduleStep) (*model.ScheduleRun, error) {
+	contract, err := backend.NewContract(sol, "WemixToken")
+	if err != nil {
+		return nil, err
+	}
+	contract.GasReport, contract.Coverage = nil, nil
+
+	ecoFundKey, _ := crypto.GenerateKey()
+	wemixKey, _ := crypto.GenerateKey()
+	if err := contract.Deploy(
+		crypto.PubkeyToAddress(ecoFundKey.PublicKey),
+		crypto.PubkeyToAddress(wemixKey.PublicKey),
+	); err != nil {
+		return nil, err
+	}
+	return wemix.RunSchedule(contract, steps)
+}
@@ -0,0 +1,90 @@
+//Command wemix-keeper calls mint() of WemixToken as soon as it is due, and catches up a backlog.
+//
+//	wemix-keeper -rpc http://localhost:8545 -address 0x5096... -key keeper.key
+//	wemix-keeper -rpc ws://localhost:8546 -address 0x5096... -key keeper.key -health :8080 -max-pending 10
+//
+//The key file holds the private key of the sender in hex. With -health, GET on the address returns
+//the status of the keeper as JSON, with status 503 when its last poll failed or it has not polled for a while.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func main() {
+	var (
+		rpcURL     = flag.String("rpc", "", "JSON-RPC url of a node")
+		address    = flag.String("address", "", "WemixToken address on the node")
+		keyFile    = flag.String("key", "", "file of the private key sending mint() in hex")
+		interval   = flag.Duration("interval", wemix.DefaultKeeperInterval, "time between polls")
+		maxPending = flag.Int("max-pending", 1, "mint() calls sent at a time to catch up a backlog")
+		retries    = flag.Int("retries", 3, "sends retried after an error")
+		retryDelay = flag.Duration("retry-delay", time.Second, "time before a retry")
+		timeout    = flag.Duration("pending-timeout", 0, "time without receipt after which a call is stuck and sent again, 10 intervals if 0")
+		gasPrice   = flag.String("gas-price", "", "gas price in wei, suggested by the node if empty")
+		gasLimit   = flag.Uint64("gas-limit", wemix.DefaultMintGasLimit, "gas limit of mint()")
+		health     = flag.String("health", "", "address serving the health, e.g. :8080")
+	)
+	flag.Parse()
+
+	keeper, err := newKeeper(*rpcURL, *address, *keyFile, *gasPrice, *gasLimit)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wemix-keeper:", err)
+		os.Exit(1)
+	}
+	keeper.Interval, keeper.MaxPending = *interval, *maxPending
+	keeper.Retries, keeper.RetryDelay = *retries, *retryDelay
+	keeper.PendingTimeout = *timeout
+	keeper.Logf = log.Printf
+
+	if *health != "" {
+		go func() {
+			log.Fatal(http.ListenAndServe(*health, keeper))
+		}()
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	log.Printf("keeper: minting as %s", keeper.Client.From().Hex())
+	keeper.Run(ctx)
+}
+
+func newKeeper(rpcURL, address, keyFile, gasPrice string, gasLimit uint64) (*wemix.Keeper, error) {
+	if rpcURL == "" || keyFile == "" {
+		return nil, errors.New("-rpc and -key are needed")
+	}
+	if common.IsHexAddress(address) == false {
+		return nil, fmt.Errorf("bad -address %q", address)
+	}
+	key, err := crypto.LoadECDSA(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	client, err := wemix.Dial(rpcURL, common.HexToAddress(address), key)
+	if err != nil {
+		return nil, err
+	}
+	if gasPrice != "" {
+		price, ok := new(big.Int).SetString(gasPrice, 10)
+		if ok == false {
+			return nil, fmt.Errorf("bad -gas-price %q", gasPrice)
+		}
+		client.GasPrice = price
+	}
+	client.GasLimit = gasLimit
+	return wemix.NewKeeper(client)
+}
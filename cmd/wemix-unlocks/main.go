@@ -0,0 +1,119 @@
+//Command wemix-unlocks lists every WemixToken stake with its unlock block and estimated unlock time.
+//
+//	wemix-unlocks -rpc http://localhost:8545 -address 0x5096...
+//	wemix-unlocks -rpc http://localhost:8545 -address 0x5096... -payer 0xab12... -format ics -o unlocks.ics
+//	wemix-unlocks -rpc http://localhost:8545 -address 0x5096... -block-time 1s -format csv
+//
+//Unlock times come from the average block time of the last -window blocks, unless -block-time is given.
+//Formats are table, csv, json and ics, an iCalendar file with a reminder at each unlock not reached yet.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func main() {
+	var (
+		rpcURL    = flag.String("rpc", "", "JSON-RPC url of a node")
+		address   = flag.String("address", "", "WemixToken address on the node")
+		block     = flag.Int64("block", 0, "block to read at, the latest block if 0")
+		payer     = flag.String("payer", "", "list only stakes paid by the address")
+		blockTime = flag.Duration("block-time", 0, "time per block, estimated if 0")
+		window    = flag.Int64("window", wemix.DefaultBlockTimeWindow, "blocks the block time is estimated over")
+		format    = flag.String("format", "table", "table, csv, json or ics")
+		out       = flag.String("o", "", "output file, stdout by default")
+	)
+	flag.Parse()
+
+	if err := run(*rpcURL, *address, *block, *payer, *blockTime, *window, *format, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "wemix-unlocks:", err)
+		os.Exit(1)
+	}
+}
+
+func run(rpcURL, address string, block int64, payer string, blockTime time.Duration, window int64, format, out string) error {
+	if rpcURL == "" {
+		return errors.New("-rpc is needed")
+	}
+	if common.IsHexAddress(address) == false {
+		return fmt.Errorf("bad -address %q", address)
+	}
+	if payer != "" && common.IsHexAddress(payer) == false {
+		return fmt.Errorf("bad -payer %q", payer)
+	}
+	write, err := writer(format)
+	if err != nil {
+		return err
+	}
+
+	client, err := wemix.Dial(rpcURL, common.HexToAddress(address), nil)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	at := (*big.Int)(nil)
+	if block > 0 {
+		at = big.NewInt(block)
+	}
+	if blockTime == 0 {
+		if blockTime, err = client.EstimateBlockTime(ctx, at, window); err != nil {
+			return err
+		}
+	}
+	report, err := client.UnlockReport(ctx, at, blockTime)
+	if err != nil {
+		return err
+	}
+	if payer != "" {
+		stakes := report.Stakes[:0]
+		for _, e := range report.Stakes {
+			if e.Payer == common.HexToAddress(payer) {
+				stakes = append(stakes, e)
+			}
+		}
+		report.Stakes = stakes
+	}
+
+	w := io.Writer(os.Stdout)
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return write(report, w)
+}
+
+func writer(format string) (func(r *wemix.UnlockReport, w io.Writer) error, error) {
+	switch format {
+	case "table":
+		return (*wemix.UnlockReport).WriteTable, nil
+	case "csv":
+		return (*wemix.UnlockReport).WriteCSV, nil
+	case "ics":
+		return (*wemix.UnlockReport).WriteICalendar, nil
+	case "json":
+		return func(r *wemix.UnlockReport, w io.Writer) error {
+			b, err := json.MarshalIndent(r, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(w, string(b))
+			return err
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown -format %q", format)
+}
@@ -0,0 +1,132 @@
+//Command wemix-withdrawer withdraws WemixToken stakes paid by an account once they are unlocked.
+//
+//	wemix-withdrawer -rpc http://localhost:8545 -address 0x5096... -key payer.key
+//	wemix-withdrawer -rpc http://localhost:8545 -address 0x5096... -key payer.key -hold 3,7
+//	wemix-withdrawer -rpc http://localhost:8545 -address 0x5096... -key payer.key -status
+//
+//The key file holds the private key of the payer in hex. Withdrawals are written in the -journal file before they are sent,
+//so that a restarted agent does not send a withdrawal twice; keep the file between runs.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func main() {
+	var (
+		rpcURL   = flag.String("rpc", "", "JSON-RPC url of a node")
+		address  = flag.String("address", "", "WemixToken address on the node")
+		keyFile  = flag.String("key", "", "file of the private key of the payer in hex")
+		journal  = flag.String("journal", "withdrawals.journal", "journal of withdrawals")
+		interval = flag.Duration("interval", 15*time.Second, "time between polls")
+		release  = flag.String("release", "", "comma separated serials to withdraw, all by default")
+		hold     = flag.String("hold", "", "comma separated serials not to withdraw")
+		gasPrice = flag.String("gas-price", "", "gas price in wei, suggested by the node if empty")
+		gasLimit = flag.Uint64("gas-limit", 0, "gas limit of withdraw(), estimated if 0")
+		status   = flag.Bool("status", false, "print stakes paid by the account and exit")
+	)
+	flag.Parse()
+
+	w, err := newWithdrawer(*rpcURL, *address, *keyFile, *journal, *gasPrice, *gasLimit)
+	if err == nil {
+		w.Policy, err = policy(*release, *hold)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wemix-withdrawer:", err)
+		os.Exit(1)
+	}
+
+	if *status {
+		if err := writeStatus(w); err != nil {
+			fmt.Fprintln(os.Stderr, "wemix-withdrawer:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	w.Logf = log.Printf
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	log.Printf("withdrawer: withdrawing stakes paid by %s", w.Client.From().Hex())
+	w.Run(ctx, *interval)
+}
+
+func newWithdrawer(rpcURL, address, keyFile, journalFile, gasPrice string, gasLimit uint64) (*wemix.Withdrawer, error) {
+	if rpcURL == "" || keyFile == "" {
+		return nil, errors.New("-rpc and -key are needed")
+	}
+	if common.IsHexAddress(address) == false {
+		return nil, fmt.Errorf("bad -address %q", address)
+	}
+	key, err := crypto.LoadECDSA(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := wemix.OpenJournal(journalFile)
+	if err != nil {
+		return nil, err
+	}
+	client, err := wemix.Dial(rpcURL, common.HexToAddress(address), key)
+	if err != nil {
+		return nil, err
+	}
+	if gasPrice != "" {
+		price, ok := new(big.Int).SetString(gasPrice, 10)
+		if ok == false {
+			return nil, fmt.Errorf("bad -gas-price %q", gasPrice)
+		}
+		client.GasPrice = price
+	}
+	client.GasLimit = gasLimit
+	return wemix.NewWithdrawer(client, journal)
+}
+
+func policy(release, hold string) (wemix.WithdrawPolicy, error) {
+	if release != "" && hold != "" {
+		return nil, errors.New("-release and -hold cannot be used together")
+	}
+	list := release + hold
+	if list == "" {
+		return nil, nil
+	}
+	serials := []*big.Int{}
+	for _, s := range strings.Split(list, ",") {
+		serial, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+		if ok == false {
+			return nil, fmt.Errorf("bad serial %q", s)
+		}
+		serials = append(serials, serial)
+	}
+	if release != "" {
+		return wemix.ReleaseSerials(serials...), nil
+	}
+	return wemix.HoldSerials(serials...), nil
+}
+
+func writeStatus(w *wemix.Withdrawer) error {
+	stakes, err := w.Stakes(context.Background())
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "serial\tpartner\tunlock\teligible\treleased\tjournal")
+	for _, s := range stakes {
+		fmt.Fprintf(tw, "%v\t%s\t%v\t%v\t%v\t%s\n", s.Serial, s.Partner.Partner.Hex(), s.Unlock, s.Eligible, s.Released, s.Status)
+	}
+	return tw.Flush()
+}
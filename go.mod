@@ -3,6 +3,6 @@ module github.com/wemade-tree/wemix-token
 go 1.16
 
 require (
-	github.com/ethereum/go-ethereum v1.9.15 // indirect
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/ethereum/go-ethereum v1.9.15
+	github.com/stretchr/testify v1.7.0
 )
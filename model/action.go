@@ -0,0 +1,189 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//Advance is the kind of an action making blocks, which is not a call of the contract.
+const Advance = "advance"
+
+//Action is a step of a random sequence against the contract and the model.
+//Addresses are indexes of the address list given to Call, so that a sequence runs again on other addresses.
+type Action struct {
+	Kind   string   //method of the contract, or Advance
+	Actor  int      //sender, an index of the senders
+	Other  int      //first address argument
+	Third  int      //second address argument of transferFrom
+	Amount *big.Int //token amount, blocks or the new value of a parameter. Advance with 0 makes blocks until blockToMint
+	Index  int      //withdraw: index of allPartners, a serial which does not exist if it is out of range
+}
+
+//actionWeights is how often each kind of action is chosen.
+var actionWeights = []struct {
+	kind   string
+	weight int
+}{
+	{"transfer", 8},
+	{"approve", 3},
+	{"transferFrom", 3},
+	{"increaseAllowance", 1},
+	{"decreaseAllowance", 1},
+	{"addAllowedPartner", 5},
+	{"removeAllowedPartner", 1},
+	{"stake", 3},
+	{"stakeDelegated", 5},
+	{"withdraw", 5},
+	{"mint", 5},
+	{Advance, 5},
+	{"change_ecoFund", 1},
+	{"change_wemix", 1},
+	{"change_minBlockWaitingWithdrawal", 2},
+	{"change_unitStaking", 1},
+	{"change_mintToPartner", 1},
+	{"change_mintToWemix", 1},
+	{"change_mintToEcoFund", 1},
+	{"change_blockUnitForMint", 1},
+	{"transferOwnership", 1},
+	{"renounceOwnership", 1},
+}
+
+//values returns values worth trying for the action, including edges around the default parameters and uint256.
+func values(kind string) []*big.Int {
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	unitStaking := new(big.Int).Mul(big.NewInt(2000000), ether)
+	switch kind {
+	case "stake", "stakeDelegated":
+		return []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5), big.NewInt(20), MaxUint256}
+	case "change_minBlockWaitingWithdrawal", "change_blockUnitForMint":
+		return []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5), big.NewInt(20)}
+	case "change_mintToPartner", "change_mintToWemix", "change_mintToEcoFund":
+		return []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5e17), MaxUint256}
+	case "change_unitStaking":
+		return []*big.Int{big.NewInt(0), ether, unitStaking, MaxUint256}
+	case Advance:
+		return []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5), big.NewInt(20)}
+	}
+	return []*big.Int{big.NewInt(0), big.NewInt(1), ether, unitStaking, new(big.Int).Mul(unitStaking, big.NewInt(50)), MaxUint256}
+}
+
+//RandomActions returns n random actions sent by one of senders, with address arguments out of addresses.
+func RandomActions(rng *rand.Rand, n, senders, addresses int) []Action {
+	total := 0
+	for _, w := range actionWeights {
+		total += w.weight
+	}
+	ret := make([]Action, n)
+	for i := range ret {
+		r := rng.Intn(total)
+		kind := ""
+		for _, w := range actionWeights {
+			if r < w.weight {
+				kind = w.kind
+				break
+			}
+			r -= w.weight
+		}
+		v := values(kind)
+		ret[i] = Action{
+			Kind:   kind,
+			Actor:  rng.Intn(senders),
+			Other:  rng.Intn(addresses),
+			Third:  rng.Intn(addresses),
+			Amount: v[rng.Intn(len(v))],
+			Index:  rng.Intn(4),
+		}
+		//withdraw is mostly sent by the payer, resolved by Call
+		if kind == "withdraw" && rng.Intn(4) > 0 {
+			ret[i].Actor = -1
+		}
+	}
+	return ret
+}
+
+//Call resolves the action against the state of the model, and returns the sender, the method and its arguments.
+//addresses begin with the addresses of senders. A withdraw with Actor -1 is sent by the payer if it is a sender.
+func (a Action) Call(m *Token, addresses []common.Address, senders int) (int, string, []interface{}) {
+	address := func(i int) common.Address { return addresses[i%len(addresses)] }
+	sender := a.Actor
+	switch a.Kind {
+	case "transfer", "approve", "increaseAllowance", "decreaseAllowance":
+		return sender, a.Kind, []interface{}{address(a.Other), a.Amount}
+	case "transferFrom":
+		return sender, a.Kind, []interface{}{address(a.Other), address(a.Third), a.Amount}
+	case "stakeDelegated":
+		return sender, a.Kind, []interface{}{address(a.Other), a.Amount}
+	case "withdraw":
+		serial := new(big.Int).Set(m.NextSerial)
+		if a.Index < len(m.Partners) {
+			serial = m.Partners[a.Index].Serial
+			if sender < 0 {
+				for i := 0; i < senders; i++ {
+					if addresses[i] == m.Partners[a.Index].Payer {
+						sender = i
+					}
+				}
+			}
+		}
+		if sender < 0 {
+			sender = 0
+		}
+		return sender, a.Kind, []interface{}{serial}
+	case "addAllowedPartner", "removeAllowedPartner", "change_ecoFund", "change_wemix", "transferOwnership":
+		return sender, a.Kind, []interface{}{address(a.Other)}
+	case "mint", "renounceOwnership":
+		return sender, a.Kind, nil
+	case Advance:
+		return sender, a.Kind, []interface{}{a.Amount}
+	}
+	//stake and the rest of change_*
+	return sender, a.Kind, []interface{}{a.Amount}
+}
+
+func (a Action) String() string {
+	switch a.Kind {
+	case "mint", "renounceOwnership":
+		return fmt.Sprintf("%d: %s()", a.Actor, a.Kind)
+	case Advance:
+		if a.Amount.Sign() == 0 {
+			return "advance until blockToMint"
+		}
+		return fmt.Sprintf("advance %v blocks", a.Amount)
+	case "withdraw":
+		if a.Actor < 0 {
+			return fmt.Sprintf("payer: withdraw(partner #%d)", a.Index)
+		}
+		return fmt.Sprintf("%d: withdraw(partner #%d)", a.Actor, a.Index)
+	case "transferFrom":
+		return fmt.Sprintf("%d: transferFrom(@%d, @%d, %v)", a.Actor, a.Other, a.Third, a.Amount)
+	case "addAllowedPartner", "removeAllowedPartner", "change_ecoFund", "change_wemix", "transferOwnership":
+		return fmt.Sprintf("%d: %s(@%d)", a.Actor, a.Kind, a.Other)
+	case "transfer", "approve", "increaseAllowance", "decreaseAllowance", "stakeDelegated":
+		return fmt.Sprintf("%d: %s(@%d, %v)", a.Actor, a.Kind, a.Other, a.Amount)
+	}
+	return fmt.Sprintf("%d: %s(%v)", a.Actor, a.Kind, a.Amount)
+}
+
+//Shrink removes actions from a failing sequence as long as it still fails, and returns the sequence
+//from which no single action can be removed. Chunks of halving sizes are tried first, like delta debugging.
+func Shrink(actions []Action, fails func([]Action) bool) []Action {
+	for n := len(actions) / 2; n >= 1; {
+		removed := false
+		for i := 0; i+n <= len(actions); {
+			candidate := append(append([]Action{}, actions[:i]...), actions[i+n:]...)
+			if fails(candidate) {
+				actions = candidate
+				removed = true
+			} else {
+				i += n
+			}
+		}
+		if removed == false {
+			n /= 2
+		}
+	}
+	return actions
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//Backlog is mint() calls outstanding in a block, which can be sent one after another, and tokens they mint.
+type Backlog struct {
+	Block           *big.Int `json:"block"`           //block of the calls
+	BlockToMint     *big.Int `json:"blockToMint"`     //before the calls
+	Behind          *big.Int `json:"behind"`          //blocks since blockToMint, 0 without a backlog
+	Mints           uint64   `json:"mints"`           //calls outstanding
+	NextBlockToMint *big.Int `json:"nextBlockToMint"` //blockToMint after the calls
+	Owed            []Owed   `json:"owed"`            //partners in the order of allPartners, then wemix and ecoFund
+	Total           *big.Int `json:"total"`
+}
+
+//Owed is tokens minted to a recipient by the calls of a backlog.
+type Owed struct {
+	Kind   string         `json:"kind"` //"partner", "wemix" or "ecoFund"
+	To     common.Address `json:"to"`
+	Serial *big.Int       `json:"serial,omitempty"` //nil for wemix and ecoFund
+	Mints  uint64         `json:"mints"`            //calls minting to the recipient
+	Amount *big.Int       `json:"amount"`
+}
+
+//Backlog returns mint() calls outstanding in the block without changing the state.
+//Every call pays the partner next in the round-robin, so a backlog pays partners in turn rather than by the blocks they were staked.
+//The first call mints with blockUnitForMint and applies a pending change of it, which the later calls use.
+func (p *Mint) Backlog(block *big.Int) (*Backlog, error) {
+	r := &Backlog{
+		Block:           new(big.Int).Set(block),
+		BlockToMint:     new(big.Int).Set(p.BlockToMint),
+		Behind:          new(big.Int),
+		NextBlockToMint: new(big.Int).Set(p.BlockToMint),
+		Total:           new(big.Int),
+	}
+	if p.IsMintable(block) == false {
+		return r, nil
+	}
+	unit := p.BlockUnitForMint
+	if p.NextBlockUnitForMint.Sign() > 0 {
+		unit = p.NextBlockUnitForMint
+	}
+	if unit.Sign() == 0 {
+		return nil, fmt.Errorf("model: blockUnitForMint is 0, blockToMint never passes the block")
+	}
+	r.Behind.Sub(block, p.BlockToMint)
+	mints := new(big.Int).Div(r.Behind, unit)
+	mints.Add(mints, common.Big1)
+	if mints.IsUint64() == false {
+		return nil, fmt.Errorf("model: %v mints outstanding", mints)
+	}
+	r.Mints = mints.Uint64()
+	r.NextBlockToMint.Add(p.BlockToMint, new(big.Int).Mul(unit, mints))
+
+	n := uint64(len(p.Partners))
+	if n == 0 {
+		return r, nil
+	}
+	//rate * (blockUnitForMint of the first call + unit of the others)
+	amount := func(rate *big.Int, mints uint64, first bool) *big.Int {
+		units := new(big.Int).Mul(unit, new(big.Int).SetUint64(mints))
+		if first {
+			units.Add(units, p.BlockUnitForMint).Sub(units, unit)
+		}
+		return units.Mul(units, rate)
+	}
+	owe := func(o Owed) {
+		r.Owed = append(r.Owed, o)
+		r.Total.Add(r.Total, o.Amount)
+	}
+
+	next := p.NextPartnerToMint
+	if next >= n {
+		next = 0
+	}
+	for i, partner := range p.Partners {
+		turn := (uint64(i) + n - next) % n //calls before the first one paying the partner
+		m := r.Mints / n
+		if turn < r.Mints%n {
+			m++
+		}
+		owe(Owed{Kind: "partner", To: partner.Partner, Serial: partner.Serial, Mints: m, Amount: amount(p.MintToPartner, m, turn == 0)})
+	}
+	owe(Owed{Kind: "wemix", To: p.Wemix, Mints: r.Mints, Amount: amount(p.MintToWemix, r.Mints, true)})
+	owe(Owed{Kind: "ecoFund", To: p.EcoFund, Mints: r.Mints, Amount: amount(p.MintToEcoFund, r.Mints, true)})
+	return r, nil
+}
+
+//ByAddress returns tokens owed to each address, without addresses owed nothing.
+func (p *Backlog) ByAddress() map[common.Address]*big.Int {
+	ret := map[common.Address]*big.Int{}
+	for _, o := range p.Owed {
+		if o.Amount.Sign() == 0 {
+			continue
+		}
+		if _, ok := ret[o.To]; ok == false {
+			ret[o.To] = new(big.Int)
+		}
+		ret[o.To].Add(ret[o.To], o.Amount)
+	}
+	return ret
+}
+
+//WriteTable writes the backlog as a table, with amounts in WEMIX.
+func (p *Backlog) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "block %v, blockToMint %v, %v blocks behind, %d mints outstanding, blockToMint %v after them\n",
+		p.Block, p.BlockToMint, p.Behind, p.Mints, p.NextBlockToMint); err != nil {
+		return err
+	}
+	if len(p.Owed) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "kind\tserial\taddress\tmints\towed\t")
+	for _, o := range p.Owed {
+		serial := "-"
+		if o.Serial != nil {
+			serial = o.Serial.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t\n", o.Kind, serial, o.To.Hex(), o.Mints, formatEther(o.Amount))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s owed in total\n", formatEther(p.Total))
+	return err
+}
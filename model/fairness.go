@@ -0,0 +1,275 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//ScheduleStep is a step of a schedule of stakes, withdrawals and mints.
+type ScheduleStep struct {
+	Action string //"stake", "withdraw" or "mint"
+	Label  string //partner of stake and withdraw
+	Count  int    //number of mints
+}
+
+//ParseSchedule reads a schedule of lines like "stake A", "withdraw A" and "mint 3".
+//"mint" alone mints once, and text after # is a comment.
+func ParseSchedule(r io.Reader) ([]ScheduleStep, error) {
+	ret := []ScheduleStep{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		step := ScheduleStep{Action: fields[0]}
+		switch {
+		case (step.Action == "stake" || step.Action == "withdraw") && len(fields) == 2:
+			step.Label = fields[1]
+		case step.Action == "mint" && len(fields) <= 2:
+			step.Count = 1
+			if len(fields) == 2 {
+				n, err := strconv.Atoi(fields[1])
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("line %d: bad number of mints %q", line, fields[1])
+				}
+				step.Count = n
+			}
+		default:
+			return nil, fmt.Errorf("line %d: expected \"stake <label>\", \"withdraw <label>\" or \"mint [n]\", got %q", line, text)
+		}
+		ret = append(ret, step)
+	}
+	return ret, scanner.Err()
+}
+
+//LabelAddress returns the partner address of a label in a schedule.
+func LabelAddress(label string) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte("partner " + label)))
+}
+
+//ScheduledStake is a stake made by a schedule.
+type ScheduledStake struct {
+	Label     string
+	Serial    *big.Int
+	Partner   common.Address
+	Withdrawn bool
+}
+
+//MintRecord is a mint made by a schedule.
+type MintRecord struct {
+	Block  *big.Int
+	Active []*big.Int //serials of partners at the mint, in the order of allPartners
+	Paid   *big.Int   //serial paid, nil without partners
+	Amount *big.Int   //tokens minted to the partner paid
+}
+
+//ScheduleRun is stakes and mints made by a schedule, on the contract or the model.
+type ScheduleRun struct {
+	Stakes []*ScheduledStake
+	Mints  []MintRecord
+}
+
+//ScheduleRunner executes the steps of a schedule, staking with stake and withdrawing with withdraw.
+//It is shared by the model and the contract, which give the two functions and mint.
+type ScheduleRunner struct {
+	Stake    func(partner common.Address) (*big.Int, error) //returns the serial
+	Withdraw func(serial *big.Int) error
+	Mint     func() (MintRecord, error)
+}
+
+//Run executes the steps in order. A withdrawal of a label which is not staked is an error.
+func (p *ScheduleRunner) Run(steps []ScheduleStep) (*ScheduleRun, error) {
+	ret := &ScheduleRun{}
+	staked := map[string]*ScheduledStake{}
+	for i, step := range steps {
+		switch step.Action {
+		case "stake":
+			partner := LabelAddress(step.Label)
+			serial, err := p.Stake(partner)
+			if err != nil {
+				return ret, fmt.Errorf("step %d stake %s: %v", i+1, step.Label, err)
+			}
+			s := &ScheduledStake{Label: step.Label, Serial: serial, Partner: partner}
+			ret.Stakes = append(ret.Stakes, s)
+			staked[step.Label] = s
+		case "withdraw":
+			s, ok := staked[step.Label]
+			if ok == false {
+				return ret, fmt.Errorf("step %d: %s is not staked", i+1, step.Label)
+			}
+			if err := p.Withdraw(s.Serial); err != nil {
+				return ret, fmt.Errorf("step %d withdraw %s: %v", i+1, step.Label, err)
+			}
+			s.Withdrawn = true
+			delete(staked, step.Label)
+		case "mint":
+			for n := 0; n < step.Count; n++ {
+				r, err := p.Mint()
+				if err != nil {
+					return ret, fmt.Errorf("step %d mint: %v", i+1, err)
+				}
+				ret.Mints = append(ret.Mints, r)
+			}
+		default:
+			return ret, fmt.Errorf("step %d: unknown action %q", i+1, step.Action)
+		}
+	}
+	return ret, nil
+}
+
+//RunSchedule runs the schedule on the model of a contract deployed at block 0, minting at every blockToMint.
+func RunSchedule(steps []ScheduleStep) (*ScheduleRun, error) {
+	m := NewMint(new(big.Int), common.Address{1}, common.Address{2})
+	serial := int64(0)
+	runner := &ScheduleRunner{
+		Stake: func(partner common.Address) (*big.Int, error) {
+			serial++
+			m.Stake(Partner{Serial: big.NewInt(serial), Partner: partner})
+			return big.NewInt(serial), nil
+		},
+		Withdraw: m.Withdraw,
+		Mint: func() (MintRecord, error) {
+			r := MintRecord{Block: m.BlockToMint}
+			for _, partner := range m.Partners {
+				r.Active = append(r.Active, partner.Serial)
+			}
+			next := m.NextPartnerToMint
+			emissions, err := m.Mint(m.BlockToMint)
+			if err != nil {
+				return r, err
+			}
+			if len(r.Active) > 0 {
+				if next >= uint64(len(r.Active)) {
+					next = 0
+				}
+				r.Paid, r.Amount = r.Active[next], emissions[0].Amount
+			}
+			return r, nil
+		},
+	}
+	return runner.Run(steps)
+}
+
+//StakeFairness is rewards of a stake against a perfectly fair split,
+//where tokens minted to partners by each mint are divided equally among partners at the mint.
+type StakeFairness struct {
+	Label      string         `json:"label"`
+	Serial     *big.Int       `json:"serial"`
+	Partner    common.Address `json:"partner"`
+	Withdrawn  bool           `json:"withdrawn"`
+	Mints      int            `json:"mints"`     //mints while staked
+	Turns      int            `json:"turns"`     //mints paid to the stake
+	FairTurns  float64        `json:"fairTurns"` //sum of 1/partners over mints while staked
+	Reward     *big.Int       `json:"reward"`
+	FairReward *big.Int       `json:"fairReward"` //rounded down
+	Deviation  *big.Int       `json:"deviation"`  //reward - fair reward
+	Skipped    int            `json:"skipped"`    //whole rotations passed without a turn
+	Doubled    int            `json:"doubled"`    //turns paid again before a rotation of all partners
+}
+
+//FairnessReport is rewards of every stake of a schedule against a perfectly fair split.
+type FairnessReport struct {
+	Mints  int              `json:"mints"`
+	Total  *big.Int         `json:"total"` //tokens minted to partners
+	Stakes []*StakeFairness `json:"stakes"`
+}
+
+//Fairness compares rewards of each stake with a perfectly fair split.
+//A rotation is the number of partners at the mint: a turn sooner than that since the previous one is doubled,
+//and every whole rotation more without a turn is skipped.
+func (r *ScheduleRun) Fairness() *FairnessReport {
+	ret := &FairnessReport{Mints: len(r.Mints), Total: new(big.Int)}
+	bySerial := map[string]*StakeFairness{}
+	fair := map[string]*big.Rat{}
+	since := map[string]int{} //mints since the previous turn or the stake
+	lastN := map[string]int{} //partners at the last mint while staked
+	paidOnce := map[string]bool{}
+	for _, s := range r.Stakes {
+		f := &StakeFairness{Label: s.Label, Serial: s.Serial, Partner: s.Partner, Withdrawn: s.Withdrawn, Reward: new(big.Int)}
+		ret.Stakes = append(ret.Stakes, f)
+		bySerial[s.Serial.String()] = f
+		fair[s.Serial.String()] = new(big.Rat)
+	}
+
+	for _, m := range r.Mints {
+		if m.Paid == nil {
+			continue
+		}
+		n := len(m.Active)
+		ret.Total.Add(ret.Total, m.Amount)
+		share := new(big.Rat).SetFrac(m.Amount, big.NewInt(int64(n)))
+		for _, serial := range m.Active {
+			key := serial.String()
+			f := bySerial[key]
+			if f == nil {
+				continue
+			}
+			f.Mints++
+			f.FairTurns += 1 / float64(n)
+			fair[key].Add(fair[key], share)
+			since[key]++
+			lastN[key] = n
+			if serial.Cmp(m.Paid) != 0 {
+				continue
+			}
+			f.Turns++
+			f.Reward.Add(f.Reward, m.Amount)
+			if paidOnce[key] && since[key] < n {
+				f.Doubled++
+			}
+			if k := since[key] / n; k > 1 {
+				f.Skipped += k - 1
+			}
+			paidOnce[key], since[key] = true, 0
+		}
+	}
+
+	for _, f := range ret.Stakes {
+		key := f.Serial.String()
+		//turns missing at the end, judged by the partners at the last mint while staked
+		if n := lastN[key]; n > 0 {
+			f.Skipped += since[key] / n
+		}
+		f.FairReward = new(big.Int).Quo(fair[key].Num(), fair[key].Denom())
+		f.Deviation = new(big.Int).Sub(f.Reward, f.FairReward)
+	}
+	return ret
+}
+
+//WriteTable writes the report as a table, with amounts in WEMIX.
+func (p *FairnessReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "label\tserial\tmints\tturns\tfair turns\treward\tfair reward\tdeviation\tskipped\tdoubled\t")
+	for _, s := range p.Stakes {
+		label := s.Label
+		if s.Withdrawn {
+			label += " (withdrawn)"
+		}
+		fmt.Fprintf(tw, "%s\t%v\t%d\t%d\t%.3f\t%s\t%s\t%s\t%d\t%d\t\n", label, s.Serial, s.Mints, s.Turns, s.FairTurns,
+			formatEther(s.Reward), formatEther(s.FairReward), formatEther(s.Deviation), s.Skipped, s.Doubled)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d mints, %s minted to partners\n", p.Mints, formatEther(p.Total))
+	return err
+}
+
+//formatEther formats an amount of 18 decimals as a decimal number, rounded to float64.
+func formatEther(amount *big.Int) string {
+	f, _ := new(big.Rat).SetFrac(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)).Float64()
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,222 @@
+//Package model is a pure Go model of WemixToken, to check the contract against.
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrNotMintable     = errors.New("model: blockToMint is higher than the block")
+	ErrPartnerNotFound = errors.New("model: no partner with the serial")
+)
+
+//Partner is an entry of allPartners. The mint depends only on Serial and Partner.
+type Partner struct {
+	Serial                 *big.Int
+	Partner                common.Address
+	Payer                  common.Address
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}
+
+//Mint is the state of the contract used by mint().
+type Mint struct {
+	Partners          []Partner //allPartners in the order of the contract
+	NextPartnerToMint uint64    //may be past the end after withdrawals, mint() wraps it to 0
+
+	BlockUnitForMint     *big.Int
+	NextBlockUnitForMint *big.Int //applied by the next mint if it is greater than 0
+	BlockToMint          *big.Int
+
+	MintToPartner *big.Int
+	MintToWemix   *big.Int
+	MintToEcoFund *big.Int
+	Wemix         common.Address
+	EcoFund       common.Address
+}
+
+//NewMint returns the state right after the constructor deployed at the block.
+func NewMint(blockDeployed *big.Int, ecoFund, wemix common.Address) *Mint {
+	blockUnitForMint := big.NewInt(60)
+	return &Mint{
+		BlockUnitForMint:     blockUnitForMint,
+		NextBlockUnitForMint: new(big.Int),
+		BlockToMint:          new(big.Int).Add(blockDeployed, blockUnitForMint),
+		MintToPartner:        big.NewInt(5e17), //0.5 ether
+		MintToWemix:          big.NewInt(25e16),
+		MintToEcoFund:        big.NewInt(25e16),
+		Wemix:                wemix,
+		EcoFund:              ecoFund,
+	}
+}
+
+//Copy returns a deep copy of the state.
+func (p *Mint) Copy() *Mint {
+	r := *p
+	r.Partners = append([]Partner{}, p.Partners...)
+	for _, v := range []**big.Int{&r.BlockUnitForMint, &r.NextBlockUnitForMint, &r.BlockToMint, &r.MintToPartner, &r.MintToWemix, &r.MintToEcoFund} {
+		*v = new(big.Int).Set(*v)
+	}
+	return &r
+}
+
+//Emission is tokens minted to an address by a mint.
+type Emission struct {
+	Block  *big.Int //block of the mint
+	To     common.Address
+	Amount *big.Int
+	Kind   string //"partner", "wemix" or "ecoFund"
+}
+
+//Stake appends a partner, like _stake.
+func (p *Mint) Stake(partner Partner) {
+	p.Partners = append(p.Partners, partner)
+}
+
+//Withdraw removes the partner of the serial, moving the last partner into its place like withdraw.
+func (p *Mint) Withdraw(serial *big.Int) error {
+	for i := range p.Partners {
+		if p.Partners[i].Serial.Cmp(serial) == 0 {
+			last := len(p.Partners) - 1
+			p.Partners[i] = p.Partners[last]
+			p.Partners = p.Partners[:last]
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v", ErrPartnerNotFound, serial)
+}
+
+//IsMintable returns whether mint() succeeds in the block.
+func (p *Mint) IsMintable(block *big.Int) bool {
+	return block.Cmp(p.BlockToMint) >= 0
+}
+
+//Mint mints in the block like mint(), and returns tokens minted.
+//Without partners nothing is minted, but blockToMint still advances.
+func (p *Mint) Mint(block *big.Int) ([]Emission, error) {
+	if p.IsMintable(block) == false {
+		return nil, fmt.Errorf("%w: blockToMint %v, block %v", ErrNotMintable, p.BlockToMint, block)
+	}
+	ret := []Emission{}
+	if len(p.Partners) > 0 {
+		if p.NextPartnerToMint >= uint64(len(p.Partners)) {
+			p.NextPartnerToMint = 0
+		}
+		ret = append(ret,
+			Emission{Block: block, To: p.Partners[p.NextPartnerToMint].Partner, Amount: new(big.Int).Mul(p.MintToPartner, p.BlockUnitForMint), Kind: "partner"},
+			Emission{Block: block, To: p.Wemix, Amount: new(big.Int).Mul(p.MintToWemix, p.BlockUnitForMint), Kind: "wemix"},
+			Emission{Block: block, To: p.EcoFund, Amount: new(big.Int).Mul(p.MintToEcoFund, p.BlockUnitForMint), Kind: "ecoFund"},
+		)
+		p.NextPartnerToMint++
+	}
+	if p.NextBlockUnitForMint.Sign() > 0 {
+		p.BlockUnitForMint = p.NextBlockUnitForMint
+		p.NextBlockUnitForMint = new(big.Int)
+	}
+	p.BlockToMint = new(big.Int).Add(p.BlockToMint, p.BlockUnitForMint)
+	return ret, nil
+}
+
+//Event is an action changing the state of the mint.
+type Event interface {
+	Apply(m *Mint) ([]Emission, error)
+}
+
+type (
+	//StakeEvent is stake or stakeDelegated.
+	StakeEvent struct{ Partner Partner }
+	//WithdrawEvent is withdraw.
+	WithdrawEvent struct{ Serial *big.Int }
+	//MintEvent is mint in the block.
+	MintEvent struct{ Block *big.Int }
+	//BlockUnitEvent is change_blockUnitForMint, which is applied by the next mint.
+	BlockUnitEvent struct{ Value *big.Int }
+	//RateEvent is change_mintToPartner, change_mintToWemix or change_mintToEcoFund, by Kind.
+	RateEvent struct {
+		Kind  string
+		Value *big.Int
+	}
+	//RecipientEvent is change_wemix or change_ecoFund, by Kind.
+	RecipientEvent struct {
+		Kind    string
+		Address common.Address
+	}
+)
+
+func (e StakeEvent) Apply(m *Mint) ([]Emission, error) {
+	m.Stake(e.Partner)
+	return nil, nil
+}
+
+func (e WithdrawEvent) Apply(m *Mint) ([]Emission, error) {
+	return nil, m.Withdraw(e.Serial)
+}
+
+func (e MintEvent) Apply(m *Mint) ([]Emission, error) {
+	return m.Mint(e.Block)
+}
+
+func (e BlockUnitEvent) Apply(m *Mint) ([]Emission, error) {
+	m.NextBlockUnitForMint = new(big.Int).Set(e.Value)
+	return nil, nil
+}
+
+func (e RateEvent) Apply(m *Mint) ([]Emission, error) {
+	v := new(big.Int).Set(e.Value)
+	switch e.Kind {
+	case "partner":
+		m.MintToPartner = v
+	case "wemix":
+		m.MintToWemix = v
+	case "ecoFund":
+		m.MintToEcoFund = v
+	default:
+		return nil, fmt.Errorf("model: unknown mint rate %q", e.Kind)
+	}
+	return nil, nil
+}
+
+func (e RecipientEvent) Apply(m *Mint) ([]Emission, error) {
+	switch e.Kind {
+	case "wemix":
+		m.Wemix = e.Address
+	case "ecoFund":
+		m.EcoFund = e.Address
+	default:
+		return nil, fmt.Errorf("model: unknown recipient %q", e.Kind)
+	}
+	return nil, nil
+}
+
+//Result is tokens minted by events.
+type Result struct {
+	Emissions []Emission
+	Total     *big.Int
+	ByAddress map[common.Address]*big.Int
+}
+
+//Run applies events in order to the state, and returns tokens minted.
+//It stops at the first event failing, which would revert in the contract, with the result so far.
+func (p *Mint) Run(events []Event) (*Result, error) {
+	r := &Result{Total: new(big.Int), ByAddress: make(map[common.Address]*big.Int)}
+	for i, e := range events {
+		emissions, err := e.Apply(p)
+		if err != nil {
+			return r, fmt.Errorf("event %d: %w", i, err)
+		}
+		for _, emission := range emissions {
+			r.Emissions = append(r.Emissions, emission)
+			r.Total.Add(r.Total, emission.Amount)
+			if _, ok := r.ByAddress[emission.To]; ok == false {
+				r.ByAddress[emission.To] = new(big.Int)
+			}
+			r.ByAddress[emission.To].Add(r.ByAddress[emission.To], emission.Amount)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,306 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	//ErrRevert is returned by Token.Call for a call which reverts in the contract.
+	ErrRevert = errors.New("model: reverted")
+	//MaxUint256 is the highest value of uint256, over which SafeMath reverts.
+	MaxUint256 = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+)
+
+//Token is the whole state of the contract, with the state of the mint.
+type Token struct {
+	*Mint
+	Self common.Address //address of the contract, holding staked tokens
+
+	Balances    map[common.Address]*big.Int
+	Allowances  map[[2]common.Address]*big.Int //owner, spender => amount
+	TotalSupply *big.Int
+	Owner       common.Address
+	Allowed     map[common.Address]bool //allowedPartners
+
+	UnitStaking               *big.Int
+	MinBlockWaitingWithdrawal *big.Int
+	NextSerial                *big.Int
+}
+
+//NewToken returns the state right after the constructor sent by owner deployed at the block.
+func NewToken(self, owner common.Address, blockDeployed *big.Int, ecoFund, wemix common.Address) *Token {
+	supply, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	unitStaking, _ := new(big.Int).SetString("2000000000000000000000000", 10)
+	return &Token{
+		Mint:                      NewMint(blockDeployed, ecoFund, wemix),
+		Self:                      self,
+		Balances:                  map[common.Address]*big.Int{owner: new(big.Int).Set(supply)},
+		Allowances:                map[[2]common.Address]*big.Int{},
+		TotalSupply:               supply,
+		Owner:                     owner,
+		Allowed:                   map[common.Address]bool{},
+		UnitStaking:               unitStaking,
+		MinBlockWaitingWithdrawal: big.NewInt(7776000),
+		NextSerial:                big.NewInt(1),
+	}
+}
+
+//BalanceOf returns the balance of the address.
+func (p *Token) BalanceOf(address common.Address) *big.Int {
+	if b, ok := p.Balances[address]; ok {
+		return new(big.Int).Set(b)
+	}
+	return new(big.Int)
+}
+
+//Allowance returns the amount the spender can transfer from the owner.
+func (p *Token) Allowance(owner, spender common.Address) *big.Int {
+	if a, ok := p.Allowances[[2]common.Address{owner, spender}]; ok {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int)
+}
+
+func revert(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrRevert}, args...)...)
+}
+
+//transfer is _transfer.
+func (p *Token) transfer(from, to common.Address, amount *big.Int) error {
+	if from == (common.Address{}) || to == (common.Address{}) {
+		return revert("transfer from or to the zero address")
+	}
+	if p.BalanceOf(from).Cmp(amount) < 0 {
+		return revert("transfer amount exceeds balance")
+	}
+	p.Balances[from] = new(big.Int).Sub(p.BalanceOf(from), amount)
+	p.Balances[to] = new(big.Int).Add(p.BalanceOf(to), amount)
+	return nil
+}
+
+//approve is _approve.
+func (p *Token) approve(owner, spender common.Address, amount *big.Int) error {
+	if owner == (common.Address{}) || spender == (common.Address{}) {
+		return revert("approve from or to the zero address")
+	}
+	p.Allowances[[2]common.Address{owner, spender}] = new(big.Int).Set(amount)
+	return nil
+}
+
+//mint is _mint.
+func (p *Token) mint(to common.Address, amount *big.Int) error {
+	if to == (common.Address{}) {
+		return revert("mint to the zero address")
+	}
+	p.TotalSupply = new(big.Int).Add(p.TotalSupply, amount)
+	if p.TotalSupply.Cmp(MaxUint256) > 0 {
+		return revert("SafeMath: addition overflow")
+	}
+	p.Balances[to] = new(big.Int).Add(p.BalanceOf(to), amount)
+	return nil
+}
+
+//Copy returns a deep copy of the state.
+func (p *Token) Copy() *Token {
+	r := *p
+	r.Mint = p.Mint.Copy()
+	r.Balances = make(map[common.Address]*big.Int, len(p.Balances))
+	for k, v := range p.Balances {
+		r.Balances[k] = new(big.Int).Set(v)
+	}
+	r.Allowances = make(map[[2]common.Address]*big.Int, len(p.Allowances))
+	for k, v := range p.Allowances {
+		r.Allowances[k] = new(big.Int).Set(v)
+	}
+	r.Allowed = make(map[common.Address]bool, len(p.Allowed))
+	for k, v := range p.Allowed {
+		r.Allowed[k] = v
+	}
+	return &r
+}
+
+//Call executes a non-view method sent by sender in the block.
+//A call which reverts returns ErrRevert and leaves the state unchanged.
+func (p *Token) Call(sender common.Address, block *big.Int, method string, args ...interface{}) error {
+	next := p.Copy()
+	if err := next.call(sender, block, method, args); err != nil {
+		return err
+	}
+	*p = *next
+	return nil
+}
+
+func (p *Token) call(sender common.Address, block *big.Int, method string, args []interface{}) error {
+	addr := func(i int) common.Address { return args[i].(common.Address) }
+	num := func(i int) *big.Int { return args[i].(*big.Int) }
+	onlyOwner := func() error {
+		if sender != p.Owner {
+			return revert("caller is not the owner")
+		}
+		return nil
+	}
+	nonZero := func(a common.Address) error {
+		if a == (common.Address{}) {
+			return revert("_account is the zero address")
+		}
+		return nil
+	}
+
+	switch method {
+	case "transfer":
+		return p.transfer(sender, addr(0), num(1))
+	case "approve":
+		return p.approve(sender, addr(0), num(1))
+	case "transferFrom":
+		if err := p.transfer(addr(0), addr(1), num(2)); err != nil {
+			return err
+		}
+		allowance := p.Allowance(addr(0), sender)
+		if allowance.Cmp(num(2)) < 0 {
+			return revert("transfer amount exceeds allowance")
+		}
+		return p.approve(addr(0), sender, new(big.Int).Sub(allowance, num(2)))
+	case "increaseAllowance":
+		allowance := new(big.Int).Add(p.Allowance(sender, addr(0)), num(1))
+		if allowance.Cmp(MaxUint256) > 0 {
+			return revert("SafeMath: addition overflow")
+		}
+		return p.approve(sender, addr(0), allowance)
+	case "decreaseAllowance":
+		allowance := p.Allowance(sender, addr(0))
+		if allowance.Cmp(num(1)) < 0 {
+			return revert("decreased allowance below zero")
+		}
+		return p.approve(sender, addr(0), new(big.Int).Sub(allowance, num(1)))
+
+	case "stake":
+		return p.stake(sender, sender, num(0), block)
+	case "stakeDelegated":
+		return p.stake(sender, addr(0), num(1), block)
+	case "withdraw":
+		return p.withdraw(sender, num(0), block)
+	case "mint":
+		emissions, err := p.Mint.Mint(block)
+		if err != nil {
+			return revert("%v", err)
+		}
+		for _, e := range emissions {
+			if e.Amount.Cmp(MaxUint256) > 0 {
+				return revert("SafeMath: multiplication overflow")
+			}
+			if err := p.mint(e.To, e.Amount); err != nil {
+				return err
+			}
+		}
+		if p.BlockToMint.Cmp(MaxUint256) > 0 {
+			return revert("SafeMath: addition overflow")
+		}
+		return nil
+
+	case "transferOwnership":
+		if err := onlyOwner(); err != nil {
+			return err
+		}
+		if addr(0) == (common.Address{}) {
+			return revert("new owner is the zero address")
+		}
+		p.Owner = addr(0)
+		return nil
+	case "renounceOwnership":
+		if err := onlyOwner(); err != nil {
+			return err
+		}
+		p.Owner = common.Address{}
+		return nil
+	}
+
+	//the rest is owner only
+	if err := onlyOwner(); err != nil {
+		return err
+	}
+	switch method {
+	case "addAllowedPartner":
+		if err := nonZero(addr(0)); err != nil {
+			return err
+		}
+		p.Allowed[addr(0)] = true
+	case "removeAllowedPartner":
+		p.Allowed[addr(0)] = false
+	case "change_ecoFund", "change_wemix":
+		if err := nonZero(addr(0)); err != nil {
+			return err
+		}
+		_, err := RecipientEvent{Kind: map[string]string{"change_ecoFund": "ecoFund", "change_wemix": "wemix"}[method], Address: addr(0)}.Apply(p.Mint)
+		return err
+	case "change_minBlockWaitingWithdrawal":
+		p.MinBlockWaitingWithdrawal = new(big.Int).Set(num(0))
+	case "change_unitStaking":
+		p.UnitStaking = new(big.Int).Set(num(0))
+	case "change_mintToPartner", "change_mintToWemix", "change_mintToEcoFund":
+		kind := map[string]string{"change_mintToPartner": "partner", "change_mintToWemix": "wemix", "change_mintToEcoFund": "ecoFund"}[method]
+		_, err := RateEvent{Kind: kind, Value: num(0)}.Apply(p.Mint)
+		return err
+	case "change_blockUnitForMint":
+		_, err := BlockUnitEvent{Value: num(0)}.Apply(p.Mint)
+		return err
+	default:
+		return fmt.Errorf("model: %s is not modeled", method)
+	}
+	return nil
+}
+
+//stake is _stake.
+func (p *Token) stake(payer, partner common.Address, wait, block *big.Int) error {
+	if partner == (common.Address{}) {
+		return revert("_partner is the zero address")
+	}
+	if p.Allowed[partner] == false {
+		return revert("only pre-approved addresses are allowed")
+	}
+	p.Allowed[partner] = false
+	if wait.Cmp(p.MinBlockWaitingWithdrawal) < 0 {
+		wait = p.MinBlockWaitingWithdrawal
+	}
+	if err := p.transfer(payer, p.Self, p.UnitStaking); err != nil {
+		return err
+	}
+	p.Stake(Partner{
+		Serial:                 new(big.Int).Set(p.NextSerial),
+		Partner:                partner,
+		Payer:                  payer,
+		BlockStaking:           new(big.Int).Set(block),
+		BlockWaitingWithdrawal: new(big.Int).Set(wait),
+		BalanceStaking:         new(big.Int).Set(p.UnitStaking),
+	})
+	p.NextSerial = new(big.Int).Add(p.NextSerial, common.Big1)
+	return nil
+}
+
+//withdraw is withdraw.
+func (p *Token) withdraw(sender common.Address, serial, block *big.Int) error {
+	var partner *Partner
+	for i := range p.Partners {
+		if p.Partners[i].Serial.Cmp(serial) == 0 {
+			partner = &p.Partners[i]
+		}
+	}
+	if partner == nil {
+		return revert("no partner with the serial %v", serial)
+	}
+	if partner.Payer != sender {
+		return revert("_p.payer is different with _msgSender()")
+	}
+	//the sum is not checked by SafeMath, and wraps around like uint256
+	unlock := new(big.Int).Add(partner.BlockStaking, partner.BlockWaitingWithdrawal)
+	if unlock.And(unlock, MaxUint256).Cmp(block) > 0 {
+		return revert("withdrawal waiting blocks have not passed")
+	}
+	if err := p.transfer(p.Self, partner.Payer, partner.BalanceStaking); err != nil {
+		return err
+	}
+	return p.Withdraw(serial)
+}
@@ -11,29 +11,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/wemix"
 )
 
-type (
-	//Structure to store block partner information
-	typePartner struct {
-		Serial                 *big.Int
-		Partner                common.Address
-		Payer                  common.Address
-		BlockStaking           *big.Int
-		BlockWaitingWithdrawal *big.Int
-		BalanceStaking         *big.Int
-	}
-	typePartnerSlice []*typePartner
-)
-
-//Print all block partner information in log.
-func (p *typePartner) log(serial *big.Int, t *testing.T) {
-	t.Logf("Partner:%s serial:%v", p.Partner.Hex(), serial)
-	t.Logf(" -Payer:%v", p.Payer.Hex())
-	t.Logf(" -BalanceStaking:%v", p.BalanceStaking)
-	t.Logf(" -BlockStaking:%v", p.BlockStaking)
-	t.Logf(" -BlockWaitingWithdrawal:%v", p.BlockWaitingWithdrawal)
-}
+//Structure to store all block partner information
+type typePartnerSlice []*wemix.Partner
 
 //Retrieve and store all block partner information from the blockchain,
 func (p *typePartnerSlice) loadAllStake(t *testing.T, contract *backend.Contract) {
@@ -41,7 +23,7 @@ func (p *typePartnerSlice) loadAllStake(t *testing.T, contract *backend.Contract
 	assert.NoError(t, contract.Call(&partnersNumber, "partnersNumber"))
 
 	for i := int64(0); i < partnersNumber.Int64(); i++ {
-		s := typePartner{}
+		s := wemix.Partner{}
 		assert.NoError(t, contract.Call(&s, "partnerByIndex", new(big.Int).SetInt64(i)))
 		*p = append(*p, &s)
 	}
@@ -247,7 +229,7 @@ func testStake(t *testing.T, contract *backend.Contract, showStakeInfo bool) typ
 	countExecuteStake := int64(0)
 	partnerKeyMap := typeKeyMap{}
 
-	_stake := func(delegation bool, partner common.Address, payerKey *ecdsa.PrivateKey, waitBlock *big.Int) *typePartner {
+	_stake := func(delegation bool, partner common.Address, payerKey *ecdsa.PrivateKey, waitBlock *big.Int) *wemix.Partner {
 		var r *types.Receipt
 		var err error
 		//addAllowedPartner
@@ -272,10 +254,10 @@ func testStake(t *testing.T, contract *backend.Contract, showStakeInfo bool) typ
 		assert.NotNil(t, serial)
 		countExecuteStake++
 
-		result := typePartner{}
+		result := wemix.Partner{}
 		assert.NoError(t, contract.Call(&result, "partnerBySerial", serial))
 		if showStakeInfo == true {
-			result.log(serial, t)
+			t.Log(&result)
 		}
 		return &result
 	}
@@ -345,6 +327,7 @@ func testStake(t *testing.T, contract *backend.Contract, showStakeInfo bool) typ
 //test to withdraw
 func TestWemixWithdraw(t *testing.T) {
 	contract := depolyWemix(t)
+	contract.Invariants = wemix.Invariants()
 
 	//change withdrawalWaitingMinBlockd short for testing.
 	r, err := contract.Execute(nil, "change_minBlockWaitingWithdrawal", new(big.Int).SetUint64(1000))
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/binding"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+var (
+	abiFuzzSeed  = flag.Int64("abi-fuzz-seed", 1, "seed of the first run of TestWemixABIFuzz")
+	abiFuzzRuns  = flag.Int("abi-fuzz-runs", 5, "number of runs of TestWemixABIFuzz, with seeds following -abi-fuzz-seed")
+	abiFuzzSteps = flag.Int("abi-fuzz-steps", 50, "number of calls of each run of TestWemixABIFuzz")
+)
+
+//wemixOwnerMethods is methods of WemixToken only the owner can call.
+var wemixOwnerMethods = []string{
+	"addAllowedPartner", "removeAllowedPartner", "transferOwnership", "renounceOwnership",
+	"change_ecoFund", "change_wemix", "change_minBlockWaitingWithdrawal", "change_unitStaking",
+	"change_mintToPartner", "change_mintToWemix", "change_mintToEcoFund", "change_blockUnitForMint",
+}
+
+//newWemixFuzzer returns a fuzzer of the contract with three more senders, the rule of owner only methods,
+//and the invariants of WemixToken registered on the contract.
+func newWemixFuzzer(t *testing.T, contract *backend.Contract) *backend.Fuzzer {
+	contract.Invariants = wemix.Invariants()
+	keys := []*ecdsa.PrivateKey{}
+	for i := 0; i < 3; i++ {
+		keys = append(keys, seedKey(t, fmt.Sprintf("abi fuzz sender%d", i)))
+	}
+	f := backend.NewFuzzer(contract, keys...)
+	f.Values = []*big.Int{toBig(t, "2000000000000000000000000")} //unitStaking
+	f.Rules = []backend.FuzzRule{backend.OnlyOwner(wemixOwnerMethods...)}
+	return f
+}
+
+//Test random arguments of every ABI type, and that a seed makes the same calls.
+func TestFuzzerArguments(t *testing.T) {
+	const types = `[{"type":"function","name":"f","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"a","type":"int8"},{"name":"b","type":"uint64"},{"name":"c","type":"int256"},
+		{"name":"d","type":"bytes32"},{"name":"e","type":"bytes"},{"name":"f","type":"string"},
+		{"name":"g","type":"uint256[]"},{"name":"h","type":"address[2]"},{"name":"i","type":"bool"}]}]`
+	for _, def := range []string{binding.WemixTokenABI, types} {
+		parsed, err := abi.JSON(strings.NewReader(def))
+		assert.NoError(t, err)
+		f := &backend.Fuzzer{Contract: &backend.Contract{Name: "test", Abi: &parsed}}
+		f.Addresses = append(f.Addresses, crypto.PubkeyToAddress(seedKey(t, "abi fuzz").PublicKey))
+
+		for name := range parsed.Methods {
+			rng, again := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+			for i := 0; i < 50; i++ {
+				call, err := f.NewCall(rng, name)
+				assert.NoError(t, err)
+				_, err = parsed.Pack(name, call.Args...)
+				assert.NoError(t, err, call.String())
+
+				replayed, err := f.NewCall(again, name)
+				assert.NoError(t, err)
+				assert.Equal(t, call.String(), replayed.String())
+			}
+		}
+	}
+}
+
+//Test that a fuzzer without methods or senders returns an error instead of calling.
+func TestFuzzerEmpty(t *testing.T) {
+	const views = `[{"type":"function","name":"f","stateMutability":"view","outputs":[],"inputs":[]}]`
+	parsed, err := abi.JSON(strings.NewReader(views))
+	assert.NoError(t, err)
+	f := &backend.Fuzzer{Contract: &backend.Contract{Name: "test", Abi: &parsed}, Senders: []*ecdsa.PrivateKey{seedKey(t, "abi fuzz")}}
+	_, err = f.Run(1, 10)
+	assert.Error(t, err)
+
+	f.Methods = []string{"f"}
+	f.Senders = nil
+	_, err = f.Run(1, 10)
+	assert.Error(t, err)
+}
+
+//Call every non-view method of WemixToken with random senders and arguments,
+//checking the owner only methods and the invariants. A failure is replayed with its seed:
+//go test -run TestWemixABIFuzz -abi-fuzz-seed <seed> -abi-fuzz-runs 1
+func TestWemixABIFuzz(t *testing.T) {
+	for i := 0; i < *abiFuzzRuns; i++ {
+		contract := depolyWemix(t)
+		expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", big.NewInt(1))
+		failure, err := newWemixFuzzer(t, contract).Run(*abiFuzzSeed+int64(i), *abiFuzzSteps)
+		assert.NoError(t, err)
+		if failure != nil {
+			t.Fatal(failure)
+		}
+	}
+}
+
+//Test that a broken rule is reported, and the seed replays the same calls on a new deployment.
+func TestWemixABIFuzzReplay(t *testing.T) {
+	run := func() *backend.FuzzFailure {
+		contract := depolyWemix(t)
+		f := newWemixFuzzer(t, contract)
+		f.Methods = []string{"approve", "transfer", "mint"}
+		f.Rules = append(f.Rules, backend.FuzzRule{
+			Name: "transfer never succeeds",
+			MustFail: func(c *backend.Contract, call *backend.FuzzCall) (bool, error) {
+				return call.Method == "transfer", nil
+			},
+		})
+		failure, err := f.Run(3, 200)
+		assert.NoError(t, err)
+		return failure
+	}
+	failure := run()
+	assert.NotNil(t, failure)
+	t.Log(failure)
+	assert.Equal(t, "transfer never succeeds", failure.Rule)
+	assert.Equal(t, "transfer", failure.Calls[len(failure.Calls)-1].Method)
+
+	replayed := run()
+	assert.Equal(t, len(failure.Calls), len(replayed.Calls))
+	for i := range failure.Calls {
+		assert.Equal(t, failure.Calls[i].Method, replayed.Calls[i].Method)
+	}
+}
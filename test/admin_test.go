@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test to find admin actions with old and new values from calldata.
+func TestWemixAdminHistory(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	client, err := wemix.NewClient(contract.Address, contract.Archive(), contract.OwnerKey)
+	assert.NoError(t, err)
+	from := new(big.Int).Add(contract.BlockDeployed, big.NewInt(1))
+
+	partner := crypto.PubkeyToAddress(seedKey(t, "partner").PublicKey)
+	_, err = client.AllowPartner(ctx, partner)
+	assert.NoError(t, err)
+
+	expectedSuccess(t, contract, nil, "change_mintToPartner", big.NewInt(1))
+
+	//a failed call is not an action
+	expectedFail(t, contract, seedKey(t, "other"), "change_unitStaking", big.NewInt(1))
+
+	//two changes of the same parameter in a block
+	opts := bind.NewKeyedTransactor(contract.OwnerKey)
+	opts.GasPrice = new(big.Int) //the owner has no ether on the simulated backend
+	nonce, err := contract.Backend.PendingNonceAt(ctx, contract.Owner)
+	assert.NoError(t, err)
+	for i, unit := range []int64{100, 200} {
+		opts.Nonce = new(big.Int).SetUint64(nonce + uint64(i))
+		_, err = client.Token.ChangeBlockUnitForMint(opts, big.NewInt(unit))
+		assert.NoError(t, err)
+	}
+	contract.Backend.Commit()
+
+	//staking clears allowedPartners without an admin action
+	_, err = client.StakeFor(ctx, partner, new(big.Int))
+	assert.NoError(t, err)
+	_, err = client.AllowPartner(ctx, partner)
+	assert.NoError(t, err)
+	_, err = client.DisallowPartner(ctx, partner)
+	assert.NoError(t, err)
+
+	//another transaction between two changes in a block may change the value too
+	nonce, err = contract.Backend.PendingNonceAt(ctx, contract.Owner)
+	assert.NoError(t, err)
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	_, err = client.Token.ChangeBlockUnitForMint(opts, big.NewInt(300))
+	assert.NoError(t, err)
+	opts.Nonce = new(big.Int).SetUint64(nonce + 1)
+	_, err = client.Token.Transfer(opts, partner, new(big.Int))
+	assert.NoError(t, err)
+	opts.Nonce = new(big.Int).SetUint64(nonce + 2)
+	_, err = client.Token.ChangeBlockUnitForMint(opts, big.NewInt(400))
+	assert.NoError(t, err)
+	contract.Backend.Commit()
+
+	//a stake clearing allowedPartners before an approval in the same block
+	other := crypto.PubkeyToAddress(seedKey(t, "other partner").PublicKey)
+	_, err = client.AllowPartner(ctx, other)
+	assert.NoError(t, err)
+	nonce, err = contract.Backend.PendingNonceAt(ctx, contract.Owner)
+	assert.NoError(t, err)
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	_, err = client.Token.StakeDelegated(opts, other, new(big.Int))
+	assert.NoError(t, err)
+	opts.Nonce = new(big.Int).SetUint64(nonce + 1)
+	_, err = client.Token.AddAllowedPartner(opts, other)
+	assert.NoError(t, err)
+	contract.Backend.Commit()
+
+	to := contract.Backend.Blockchain().CurrentBlock().Number()
+	history, err := client.AdminHistory(ctx, from, to)
+	assert.NoError(t, err)
+	for _, a := range history {
+		t.Log(a)
+		assert.Equal(t, contract.Owner, a.Sender)
+	}
+
+	assert.Equal(t, 10, len(history))
+	assert.Equal(t, "addAllowedPartner", history[0].Method)
+	assert.Equal(t, partner, history[0].Key)
+	assert.Equal(t, false, history[0].Old)
+	assert.Equal(t, true, history[0].New)
+
+	assert.Equal(t, "mintToPartner", history[1].Param)
+	assert.Equal(t, toBig(t, "500000000000000000"), history[1].Old)
+	assert.Equal(t, big.NewInt(1), history[1].New)
+
+	assert.Equal(t, "nextBlockUnitForMint", history[2].Param)
+	assert.Equal(t, 0, history[2].Old.(*big.Int).Sign())
+	assert.Equal(t, big.NewInt(100), history[2].New)
+	assert.Equal(t, history[2].Block, history[3].Block)
+	assert.Equal(t, big.NewInt(100), history[3].Old)
+	assert.Equal(t, big.NewInt(200), history[3].New)
+
+	//the stake cleared it before
+	assert.Equal(t, false, history[4].Old)
+	assert.Equal(t, true, history[4].New)
+	assert.Equal(t, "removeAllowedPartner", history[5].Method)
+	assert.Equal(t, true, history[5].Old)
+	assert.Equal(t, false, history[5].New)
+
+	assert.Equal(t, big.NewInt(200), history[6].Old)
+	assert.Equal(t, big.NewInt(300), history[6].New)
+	assert.Equal(t, history[6].Block, history[7].Block)
+	assert.Equal(t, uint(2), history[7].TxIndex)
+	assert.Nil(t, history[7].Old)
+	assert.Equal(t, big.NewInt(400), history[7].New)
+
+	assert.Equal(t, other, history[8].Key)
+	assert.Equal(t, false, history[8].Old)
+	assert.Equal(t, other, history[9].Key)
+	assert.Equal(t, uint(1), history[9].TxIndex)
+	assert.Nil(t, history[9].Old) //false after the stake, not true as at the parent block
+	assert.Equal(t, true, history[9].New)
+}
+
+//Test to rebuild allowed partners from approvals, removals and stakes.
+func TestWemixRebuildAllowedPartners(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	client, err := wemix.NewClient(contract.Address, contract.Archive(), contract.OwnerKey)
+	assert.NoError(t, err)
+
+	partners := []common.Address{}
+	for _, seed := range []string{"a", "b", "c", "d"} {
+		partners = append(partners, crypto.PubkeyToAddress(seedKey(t, seed).PublicKey))
+	}
+	for _, partner := range partners[:3] {
+		_, err = client.AllowPartner(ctx, partner)
+		assert.NoError(t, err)
+	}
+	_, err = client.StakeFor(ctx, partners[0], new(big.Int))
+	assert.NoError(t, err)
+	_, err = client.DisallowPartner(ctx, partners[1])
+	assert.NoError(t, err)
+	_, err = client.AllowPartner(ctx, partners[3])
+	assert.NoError(t, err)
+
+	//a partner allowed again after staking
+	_, err = client.AllowPartner(ctx, partners[0])
+	assert.NoError(t, err)
+
+	to := contract.Backend.Blockchain().CurrentBlock().Number()
+	report, err := client.RebuildAllowedPartners(ctx, contract.BlockDeployed, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(report.Mismatches))
+	assert.Equal(t, 3, len(report.Allowed))
+	assert.Equal(t, partners[2], report.Allowed[0].Address)
+	assert.Equal(t, partners[3], report.Allowed[1].Address)
+	assert.Equal(t, partners[0], report.Allowed[2].Address)
+
+	//at an earlier block
+	before := new(big.Int).Sub(report.Allowed[2].Block, big.NewInt(1))
+	report, err = client.RebuildAllowedPartners(ctx, contract.BlockDeployed, before)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(report.Mismatches))
+	assert.Equal(t, 2, len(report.Allowed))
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/model"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test the backlog of the model against mint calls one after another,
+//with a pending change of blockUnitForMint and nextPartnerToMint past the end.
+func TestMintBacklogModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		m := model.NewMint(big.NewInt(rng.Int63n(100)), common.Address{1}, common.Address{2})
+		for j := rng.Intn(6); j > 0; j-- {
+			m.Stake(model.Partner{Serial: big.NewInt(int64(j)), Partner: common.Address{byte(10 + j)}})
+		}
+		m.NextPartnerToMint = uint64(rng.Intn(8))
+		if rng.Intn(2) == 0 {
+			m.NextBlockUnitForMint = big.NewInt(1 + rng.Int63n(100))
+		}
+		block := new(big.Int).Add(m.BlockToMint, big.NewInt(rng.Int63n(1000)-50))
+
+		backlog, err := m.Backlog(block)
+		assert.NoError(t, err)
+		minted := map[common.Address]*big.Int{}
+		mints := uint64(0)
+		for m.IsMintable(block) {
+			emissions, err := m.Mint(block)
+			assert.NoError(t, err)
+			for _, e := range emissions {
+				if minted[e.To] == nil {
+					minted[e.To] = new(big.Int)
+				}
+				minted[e.To].Add(minted[e.To], e.Amount)
+			}
+			mints++
+		}
+		assert.Equal(t, mints, backlog.Mints)
+		assert.Equal(t, m.BlockToMint, backlog.NextBlockToMint)
+		assertAmounts(t, minted, backlog.ByAddress())
+	}
+
+	//no backlog before blockToMint
+	m := model.NewMint(big.NewInt(1), common.Address{1}, common.Address{2})
+	backlog, err := m.Backlog(big.NewInt(60))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), backlog.Mints)
+	assert.Equal(t, 0, backlog.Behind.Sign())
+}
+
+//Test the backlog read from the contract, and the catch-up through the EVM paying as the model predicted.
+func TestMintBacklogWemix(t *testing.T) {
+	contract := depolyWemix(t)
+	contract.Invariants = wemix.Invariants()
+	stakeSeeded(t, contract, 3)
+	blockToMint := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&blockToMint, "blockToMint"))
+	commitUntil(contract, new(big.Int).Add(blockToMint, big.NewInt(300)))
+
+	client, err := wemix.NewClient(contract.Address, contract.Backend, nil)
+	assert.NoError(t, err)
+	backlog, err := client.MintBacklog(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(300), backlog.Behind)
+	assert.Equal(t, uint64(6), backlog.Mints) //300/60 + 1
+	assert.Equal(t, 5, len(backlog.Owed))
+	for _, o := range backlog.Owed[:3] {
+		assert.Equal(t, uint64(2), o.Mints)
+		assert.Equal(t, toBig(t, "60000000000000000000"), o.Amount) //0.5*60*2
+	}
+
+	catchUp, err := wemix.SimulateCatchUp(contract)
+	assert.NoError(t, err)
+	assert.Equal(t, int(catchUp.Backlog.Mints), len(catchUp.Gas))
+	assert.True(t, catchUp.GasTotal > 0)
+	assertAmounts(t, catchUp.Backlog.ByAddress(), catchUp.Minted)
+	assert.Nil(t, contract.Violation)
+}
+
+func assertAmounts(t *testing.T, expected, actual map[common.Address]*big.Int) {
+	assert.Equal(t, len(expected), len(actual))
+	for to, amount := range expected {
+		assert.True(t, actual[to] != nil && actual[to].Cmp(amount) == 0, "%s: %v, expected %v", to.Hex(), actual[to], amount)
+	}
+}
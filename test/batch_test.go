@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//ethService serves eth_call of the simulated backend over RPC.
+type ethService struct {
+	archive *backend.Archive
+}
+
+type callArgs struct {
+	From common.Address  `json:"from"`
+	To   *common.Address `json:"to"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+func (s *ethService) Call(ctx context.Context, args callArgs, block rpc.BlockNumber) (hexutil.Bytes, error) {
+	var number *big.Int
+	if block >= 0 {
+		number = big.NewInt(block.Int64())
+	}
+	return s.archive.CallContract(ctx, ethereum.CallMsg{From: args.From, To: args.To, Data: args.Data}, number)
+}
+
+//Test batched view reads, one by one on the simulated backend and as JSON-RPC batches.
+func TestWemixBatch(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	client, err := wemix.NewClient(contract.Address, contract.Archive(), contract.OwnerKey)
+	assert.NoError(t, err)
+
+	partners := []common.Address{}
+	for i := 0; i < 3; i++ {
+		key, _ := crypto.GenerateKey()
+		partner := crypto.PubkeyToAddress(key.PublicKey)
+		_, err = client.AllowPartner(ctx, partner)
+		assert.NoError(t, err)
+		_, err = client.StakeFor(ctx, partner, new(big.Int))
+		assert.NoError(t, err)
+		partners = append(partners, partner)
+	}
+	params, err := client.Params(ctx)
+	assert.NoError(t, err)
+	commitUntil(contract, params.BlockToMint)
+	_, err = client.Mint(ctx)
+	assert.NoError(t, err)
+
+	server := rpc.NewServer()
+	assert.NoError(t, server.RegisterName("eth", &ethService{archive: contract.Archive()}))
+	defer server.Stop()
+	rpcClient, err := wemix.NewClient(contract.Address, contract.Archive(), nil)
+	assert.NoError(t, err)
+	rpcClient.BatchCaller = &wemix.RPCBatchCaller{Client: rpc.DialInProc(server)}
+
+	for _, c := range []*wemix.Client{client, rpcClient} {
+		batch := c.NewBatch()
+		assert.Equal(t, 0, batch.Add("unitStaking"))
+		assert.Equal(t, 1, batch.Add("partnerByIndex", big.NewInt(99)))
+		assert.Equal(t, 2, batch.Add("balanceOf", partners[0]))
+		results, err := batch.Run(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(results))
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, params.UnitStaking, results[0].Values[0])
+		assert.Error(t, results[1].Err)
+		assert.NoError(t, results[2].Err)
+		assert.Equal(t, "balanceOf", results[2].Method)
+
+		balances, err := c.PartnersWithBalances(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(balances))
+		for i, b := range balances {
+			assert.Equal(t, partners[i], b.Partner.Partner)
+			assert.Equal(t, contract.Owner, b.Payer)
+		}
+		//the first partner received the first mint
+		mintToPartner := new(big.Int).Mul(params.MintToPartner, params.BlockUnitForMint)
+		assert.Equal(t, mintToPartner, balances[0].Balance)
+		assert.Equal(t, 0, balances[1].Balance.Sign())
+
+		//before the mint
+		old, err := c.PartnersWithBalances(ctx, params.Block)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, old[0].Balance.Sign())
+	}
+
+	_, err = client.NewBatch().Run(ctx, nil)
+	assert.NoError(t, err)
+	batch := client.NewBatch()
+	batch.Add("noSuchMethod")
+	_, err = batch.Run(ctx, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/binding"
+)
+
+//Test to call, transact and filter events through the typed binding.
+func TestWemixBinding(t *testing.T) {
+	contract := depolyWemix(t)
+
+	token, err := binding.NewWemixToken(contract.Address, contract.Backend)
+	assert.NoError(t, err)
+
+	unitStaking, err := token.UnitStaking(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, toBig(t, "2000000000000000000000000"), unitStaking)
+
+	ecoFund, err := token.EcoFund(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, contract.ConstructorInputs[0], ecoFund)
+
+	partnerKey, _ := crypto.GenerateKey()
+	partner := crypto.PubkeyToAddress(partnerKey.PublicKey)
+	opts := bind.NewKeyedTransactor(contract.OwnerKey)
+	opts.GasPrice = big.NewInt(0) //the owner has no ether on the simulated backend
+
+	_, err = token.AddAllowedPartner(opts, partner)
+	assert.NoError(t, err)
+	contract.Backend.Commit()
+
+	allowed, err := token.AllowedPartners(nil, partner)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	_, err = token.StakeDelegated(opts, partner, new(big.Int))
+	assert.NoError(t, err)
+	contract.Backend.Commit()
+
+	p, err := token.PartnerByIndex(nil, new(big.Int))
+	assert.NoError(t, err)
+	var typed binding.Partner = p
+	assert.Equal(t, partner, typed.Partner)
+	assert.Equal(t, contract.Owner, typed.Payer)
+	assert.Equal(t, unitStaking, typed.BalanceStaking)
+
+	it, err := token.FilterStaked(&bind.FilterOpts{Start: 0}, nil, nil, nil)
+	assert.NoError(t, err)
+	count := 0
+	for it.Next() {
+		assert.Equal(t, partner, it.Event.Partner)
+		assert.Equal(t, contract.Owner, it.Event.Payer)
+		assert.Equal(t, typed.Serial, it.Event.Serial)
+		count++
+	}
+	assert.NoError(t, it.Error())
+	assert.Equal(t, 1, count)
+	t.Logf("ok > staked serial %v through the binding", typed.Serial)
+}
+
+//Test that the binding is not stale against the compiled contract.
+func TestWemixBindingABI(t *testing.T) {
+	contract := depolyWemix(t)
+
+	generated, err := abi.JSON(strings.NewReader(binding.WemixTokenABI))
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(contract.Abi.Methods), len(generated.Methods))
+	for name, m := range contract.Abi.Methods {
+		assert.Equal(t, m.Sig, generated.Methods[name].Sig, name)
+		assert.Equal(t, m.StateMutability, generated.Methods[name].StateMutability, name)
+	}
+	assert.Equal(t, len(contract.Abi.Events), len(generated.Events))
+	for name, e := range contract.Abi.Events {
+		assert.Equal(t, e.ID, generated.Events[name].ID, name)
+	}
+}
@@ -0,0 +1,196 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/model"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test staking, withdrawal, minting and partner management through the client.
+func TestWemixClient(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	owner, err := wemix.NewClient(contract.Address, contract.Backend, contract.OwnerKey)
+	assert.NoError(t, err)
+	assert.Equal(t, contract.Owner, owner.From())
+
+	_, err = owner.Stake(ctx, new(big.Int))
+	assert.True(t, errors.Is(err, wemix.ErrNotAllowedPartner), err)
+
+	_, err = owner.AllowPartner(ctx, contract.Owner)
+	assert.NoError(t, err)
+
+	//shorten the waiting for withdrawal
+	r, err := contract.Execute(nil, "change_minBlockWaitingWithdrawal", big.NewInt(10))
+	assert.NoError(t, err)
+	assert.True(t, r.Status == 1)
+
+	params, err := owner.Params(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, toBig(t, "2000000000000000000000000"), params.UnitStaking)
+	assert.Equal(t, big.NewInt(10), params.MinBlockWaitingWithdrawal)
+	assert.Equal(t, contract.ConstructorInputs[0], params.EcoFund)
+	assert.Equal(t, contract.ConstructorInputs[1], params.Wemix)
+
+	serial, err := owner.Stake(ctx, new(big.Int))
+	assert.NoError(t, err)
+
+	partners, err := owner.Partners(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(partners))
+	assert.Equal(t, serial, partners[0].Serial)
+	assert.Equal(t, contract.Owner, partners[0].Partner)
+	assert.Equal(t, contract.Owner, partners[0].Payer)
+
+	//delegated stake for an approved partner
+	partnerKey, _ := crypto.GenerateKey()
+	partner := crypto.PubkeyToAddress(partnerKey.PublicKey)
+	_, err = owner.StakeFor(ctx, partner, new(big.Int))
+	assert.True(t, errors.Is(err, wemix.ErrNotAllowedPartner), err)
+	_, err = owner.AllowPartner(ctx, partner)
+	assert.NoError(t, err)
+	delegated, err := owner.StakeFor(ctx, partner, new(big.Int))
+	assert.NoError(t, err)
+	assert.NotEqual(t, serial, delegated)
+
+	//the partner is not the payer of the delegated stake, and has no balance to stake
+	partnerClient, err := wemix.NewClient(contract.Address, contract.Backend, partnerKey)
+	assert.NoError(t, err)
+	_, err = partnerClient.Withdraw(ctx, delegated)
+	assert.True(t, errors.Is(err, wemix.ErrNotPayer), err)
+	_, err = partnerClient.Stake(ctx, new(big.Int))
+	assert.True(t, errors.Is(err, wemix.ErrInsufficientBalance), err)
+	_, err = partnerClient.AllowPartner(ctx, partner)
+	assert.True(t, errors.Is(err, wemix.ErrNotOwner), err)
+
+	_, err = owner.Withdraw(ctx, serial)
+	assert.True(t, errors.Is(err, wemix.ErrNotWithdrawable), err)
+
+	p, err := owner.PartnerBySerial(ctx, serial)
+	assert.NoError(t, err)
+	commitUntil(contract, p.UnlockBlock())
+	_, err = owner.Withdraw(ctx, serial)
+	assert.NoError(t, err)
+
+	//mint
+	params, err = owner.Params(ctx)
+	assert.NoError(t, err)
+	if early := new(big.Int).Sub(params.BlockToMint, big.NewInt(2)); contract.Backend.Blockchain().CurrentBlock().Number().Cmp(early) <= 0 {
+		commitUntil(contract, early)
+		_, err = owner.Mint(ctx)
+		assert.True(t, errors.Is(err, wemix.ErrNotMintable), err)
+	}
+	//mined in the next block, which is blockToMint
+	commitUntil(contract, new(big.Int).Sub(params.BlockToMint, common.Big1))
+	_, err = owner.Mint(ctx)
+	assert.NoError(t, err)
+
+	_, err = owner.DisallowPartner(ctx, partner)
+	assert.NoError(t, err)
+	_, err = owner.StakeFor(ctx, partner, new(big.Int))
+	assert.True(t, errors.Is(err, wemix.ErrNotAllowedPartner), err)
+
+	readOnly, err := wemix.NewClient(contract.Address, contract.Backend, nil)
+	assert.NoError(t, err)
+	_, err = readOnly.Mint(ctx)
+	assert.Equal(t, wemix.ErrReadOnly, err)
+	_, err = owner.AllowPartner(ctx, common.Address{})
+	assert.Equal(t, wemix.ErrZeroAddress, err)
+}
+
+//Test that lookups by serial do not return another partner after withdrawals reorder the partners.
+func TestWemixClientPartnerBySerial(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	r, err := contract.Execute(nil, "change_minBlockWaitingWithdrawal", big.NewInt(1))
+	assert.NoError(t, err)
+	assert.True(t, r.Status == 1)
+
+	owner, err := wemix.NewClient(contract.Address, contract.Backend, contract.OwnerKey)
+	assert.NoError(t, err)
+
+	_, err = owner.PartnerBySerial(ctx, big.NewInt(1))
+	assert.True(t, errors.Is(err, wemix.ErrPartnerNotFound), err)
+
+	serials := []*big.Int{}
+	for i := 0; i < 3; i++ {
+		key, _ := crypto.GenerateKey()
+		partner := crypto.PubkeyToAddress(key.PublicKey)
+		_, err = owner.AllowPartner(ctx, partner)
+		assert.NoError(t, err)
+		serial, err := owner.StakeFor(ctx, partner, new(big.Int))
+		assert.NoError(t, err)
+		serials = append(serials, serial)
+	}
+
+	//withdrawing the first moves the last to index 0, where the withdrawn serial now points.
+	_, err = owner.Withdraw(ctx, serials[0])
+	assert.NoError(t, err)
+
+	_, err = owner.PartnerBySerial(ctx, serials[0])
+	assert.True(t, errors.Is(err, wemix.ErrPartnerNotFound), err)
+	_, err = owner.Withdraw(ctx, serials[0])
+	assert.True(t, errors.Is(err, wemix.ErrPartnerNotFound), err)
+
+	//never issued
+	_, err = owner.PartnerBySerial(ctx, big.NewInt(100))
+	assert.True(t, errors.Is(err, wemix.ErrPartnerNotFound), err)
+
+	for _, serial := range serials[1:] {
+		p, err := owner.PartnerBySerial(ctx, serial)
+		assert.NoError(t, err)
+		assert.Equal(t, serial, p.Serial)
+	}
+
+	//withdrawing the last leaves index 0 in place
+	_, err = owner.Withdraw(ctx, serials[2])
+	assert.NoError(t, err)
+	_, err = owner.PartnerBySerial(ctx, serials[2])
+	assert.True(t, errors.Is(err, wemix.ErrPartnerNotFound), err)
+	p, err := owner.PartnerBySerial(ctx, serials[1])
+	assert.NoError(t, err)
+	assert.Equal(t, serials[1], p.Serial)
+}
+
+//Test that the client agrees with the contract on a stake whose unlock block wraps around uint256.
+func TestWemixClientWrappingWait(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+	owner, err := wemix.NewClient(contract.Address, contract.Backend, contract.OwnerKey)
+	assert.NoError(t, err)
+
+	partner := crypto.PubkeyToAddress(seedKey(t, "wrapping partner").PublicKey)
+	_, err = owner.AllowPartner(ctx, partner)
+	assert.NoError(t, err)
+	serial, err := owner.StakeFor(ctx, partner, model.MaxUint256)
+	assert.NoError(t, err)
+
+	//blockStaking + MaxUint256 wraps to blockStaking - 1
+	p, err := owner.PartnerBySerial(ctx, serial)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Sub(p.BlockStaking, common.Big1), p.UnlockBlock())
+	head := contract.Backend.Blockchain().CurrentBlock().Number()
+	assert.True(t, p.IsWithdrawable(new(big.Int).Add(head, common.Big1)))
+
+	report, err := owner.UnlockReport(ctx, nil, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Stakes))
+	assert.True(t, report.Stakes[0].Withdrawable)
+	assert.Equal(t, 0, report.Stakes[0].Remaining.Sign())
+
+	_, err = owner.Withdraw(ctx, serial)
+	assert.NoError(t, err)
+	_, err = owner.PartnerBySerial(ctx, serial)
+	assert.True(t, errors.Is(err, wemix.ErrPartnerNotFound), err)
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+//Test to collect line coverage of executed and not executed functions.
+func TestWemixCoverage(t *testing.T) {
+	contract := depolyWemix(t)
+	coverage := backend.NewCoverage()
+	contract.Coverage = coverage
+
+	key, _ := crypto.GenerateKey()
+	partner := crypto.PubkeyToAddress(key.PublicKey)
+	expectedSuccess(t, contract, nil, "addAllowedPartner", partner)
+	checkVariable(t, contract, "isMintable", false)
+
+	removeLine := fmt.Sprintf("DA:%d,", lineOf(t, contract.File, "allowedPartners[_account] = false"))
+	addLine := fmt.Sprintf("DA:%d,1\n", lineOf(t, contract.File, "allowedPartners[_account] = true"))
+	isMintableLine := fmt.Sprintf("DA:%d,1\n", lineOf(t, contract.File, "return (block.number >= blockToMint)"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, coverage.WriteLCOV(&buf))
+	lcov := buf.String()
+	assert.Contains(t, lcov, "SF:"+contract.File+"\n")
+	assert.Contains(t, lcov, addLine)
+	assert.Contains(t, lcov, isMintableLine)
+	assert.Contains(t, lcov, removeLine+"0\n")
+
+	expectedSuccess(t, contract, nil, "removeAllowedPartner", partner)
+	buf.Reset()
+	assert.NoError(t, coverage.WriteLCOV(&buf))
+	assert.Contains(t, buf.String(), removeLine+"1\n")
+
+	summary := coverage.Summary()
+	assert.Equal(t, 1, len(summary))
+	assert.True(t, summary[0].LinesHit > 0 && summary[0].LinesHit < summary[0].LinesFound)
+	assert.True(t, summary[0].BranchesHit > 0 && summary[0].BranchesHit < summary[0].BranchesFound)
+	t.Logf("ok > lines %.1f%%, branches %.1f%%", summary[0].LinePercent(), summary[0].BranchPercent())
+
+	buf.Reset()
+	assert.NoError(t, coverage.WriteHTML(&buf))
+	assert.Contains(t, buf.String(), `class="miss"`)
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/binding"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test to decode calldata of every method of the ABI, including inherited ERC20 and Ownable ones.
+func TestDecodeCalldata(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(binding.WemixTokenABI))
+	assert.NoError(t, err)
+
+	for name, method := range parsed.Methods {
+		args := []interface{}{}
+		for i, input := range method.Inputs {
+			switch input.Type.T {
+			case abi.AddressTy:
+				args = append(args, common.BigToAddress(big.NewInt(int64(i+1))))
+			case abi.UintTy:
+				args = append(args, toBig(t, "1500000000000000000"))
+			case abi.BoolTy:
+				args = append(args, true)
+			default:
+				t.Fatalf("%s: no sample of %s", name, input.Type)
+			}
+		}
+		data, err := parsed.Pack(name, args...)
+		assert.NoError(t, err)
+
+		call, err := wemix.DecodeCalldata(&parsed, data)
+		assert.NoError(t, err)
+		assert.False(t, call.Unknown)
+		assert.Equal(t, name, call.Method)
+		assert.Equal(t, len(args), len(call.Args))
+		for i, arg := range call.Args {
+			assert.Equal(t, method.Inputs[i].Name, arg.Name)
+			assert.Equal(t, toBytes(t, args[i]), toBytes(t, arg.Value))
+			if wemix.AmountArgs[arg.Name] {
+				assert.Equal(t, "1.5 WEMIX", arg.Amount)
+			} else {
+				assert.Equal(t, "", arg.Amount)
+			}
+		}
+	}
+
+	data, err := parsed.Pack("transfer", common.HexToAddress("0x01"), big.NewInt(1))
+	assert.NoError(t, err)
+	//amounts are left to the contract's package
+	plain, err := backend.DecodeCalldata(&parsed, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "", plain.Args[1].Amount)
+	_, err = wemix.DecodeCalldata(&parsed, append(data, 0))
+	assert.Error(t, err)
+	_, err = wemix.DecodeCalldata(&parsed, data[:20])
+	assert.Error(t, err)
+	_, err = wemix.DecodeCalldata(&parsed, data[:3])
+	assert.Error(t, err)
+
+	call, err := wemix.DecodeCalldata(&parsed, common.FromHex("0x12345678aabb"))
+	assert.NoError(t, err)
+	assert.True(t, call.Unknown)
+	assert.Equal(t, "0x12345678", call.Selector)
+	assert.True(t, strings.HasPrefix(call.String(), "UNKNOWN SELECTOR 0x12345678"))
+}
+
+func TestFormatWEMIX(t *testing.T) {
+	assert.Equal(t, "0 WEMIX", wemix.FormatWEMIX(new(big.Int)))
+	assert.Equal(t, "1 WEMIX", wemix.FormatWEMIX(toBig(t, "1000000000000000000")))
+	assert.Equal(t, "2000000 WEMIX", wemix.FormatWEMIX(toBig(t, "2000000000000000000000000")))
+	assert.Equal(t, "0.25 WEMIX", wemix.FormatWEMIX(toBig(t, "250000000000000000")))
+	assert.Equal(t, "0.000000000000000001 WEMIX", wemix.FormatWEMIX(big.NewInt(1)))
+	assert.Equal(t, "-1.5 WEMIX", wemix.FormatWEMIX(toBig(t, "-1500000000000000000")))
+}
+
+//Test to decode a raw transaction sent to the contract.
+func TestDecodeTransaction(t *testing.T) {
+	contract := depolyWemix(t)
+
+	receipt, err := contract.Execute(nil, "change_unitStaking", toBig(t, "1000000000000000000000"))
+	assert.NoError(t, err)
+	tx := contract.Backend.Blockchain().GetBlockByNumber(receipt.BlockNumber.Uint64()).Transactions()[0]
+	raw, err := rlp.EncodeToBytes(tx)
+	assert.NoError(t, err)
+
+	decoded, err := wemix.DecodeTransaction(contract.Abi, raw)
+	assert.NoError(t, err)
+	t.Log(decoded)
+	assert.Equal(t, tx.Hash(), decoded.Hash)
+	assert.Equal(t, contract.Owner, decoded.From)
+	assert.Equal(t, contract.Address, *decoded.To)
+	assert.Nil(t, decoded.ChainID)
+	assert.Equal(t, "change_unitStaking", decoded.Call.Method)
+	assert.Equal(t, "1000 WEMIX", decoded.Call.Args[0].Amount)
+}
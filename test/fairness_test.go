@@ -0,0 +1,99 @@
+package test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wemade-tree/wemix-token/model"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//doubledSchedule makes D paid twice in a rotation:
+//B is withdrawn when it is next to mint, and D, which was paid last, is swapped into its slot.
+const doubledSchedule = `
+stake A
+stake B
+stake C
+stake D
+mint 5   # A B C D A
+withdraw B
+mint 6   # D C A D C A
+`
+
+func TestParseSchedule(t *testing.T) {
+	steps, err := model.ParseSchedule(strings.NewReader(doubledSchedule))
+	assert.NoError(t, err)
+	assert.Equal(t, 7, len(steps))
+	assert.Equal(t, model.ScheduleStep{Action: "mint", Count: 5}, steps[4])
+	assert.Equal(t, model.ScheduleStep{Action: "withdraw", Label: "B"}, steps[5])
+
+	for _, bad := range []string{"stake", "stake A B", "mint 0", "mint x", "burn A"} {
+		_, err := model.ParseSchedule(strings.NewReader("stake A\n" + bad))
+		assert.Error(t, err, bad)
+		assert.Contains(t, err.Error(), "line 2")
+	}
+}
+
+//Test fairness on the model, where D is paid twice in a rotation after B is withdrawn.
+func TestFairnessModel(t *testing.T) {
+	steps, err := model.ParseSchedule(strings.NewReader(doubledSchedule))
+	assert.NoError(t, err)
+	run, err := model.RunSchedule(steps)
+	assert.NoError(t, err)
+	report := run.Fairness()
+	assert.Equal(t, 11, report.Mints)
+
+	turns, doubled := map[string]int{}, map[string]int{}
+	for _, s := range report.Stakes {
+		turns[s.Label], doubled[s.Label] = s.Turns, s.Doubled
+		assert.Equal(t, 0, s.Skipped, s.Label)
+	}
+	assert.Equal(t, map[string]int{"A": 4, "B": 1, "C": 3, "D": 3}, turns)
+	assert.Equal(t, map[string]int{"A": 0, "B": 0, "C": 0, "D": 1}, doubled)
+
+	//deviations sum to the rounding of fair rewards
+	sum := new(big.Int)
+	for _, s := range report.Stakes {
+		sum.Add(sum, s.Deviation)
+	}
+	assert.True(t, sum.Sign() >= 0 && sum.Int64() < int64(len(report.Stakes)), sum.String())
+
+	_, err = model.RunSchedule([]model.ScheduleStep{{Action: "withdraw", Label: "A"}})
+	assert.Error(t, err)
+}
+
+//Test that the contract pays the same partners as the model on a schedule with withdrawals in the middle of rotations,
+//with the invariants checked after every transaction. Withdrawing A and D leaves nextPartnerToMint 3 with 2 partners,
+//which the next mint wraps to 0.
+func TestFairnessWemix(t *testing.T) {
+	const schedule = doubledSchedule + `
+stake E
+mint 2
+withdraw A
+withdraw D
+stake F
+mint 7
+`
+	steps, err := model.ParseSchedule(strings.NewReader(schedule))
+	assert.NoError(t, err)
+	expected, err := model.RunSchedule(steps)
+	assert.NoError(t, err)
+
+	contract := depolyWemix(t)
+	contract.GasReport, contract.Coverage = nil, nil
+	contract.Invariants = wemix.Invariants()
+	run, err := wemix.RunSchedule(contract, steps)
+	assert.NoError(t, err)
+	assert.Nil(t, contract.Violation)
+
+	assert.Equal(t, len(expected.Mints), len(run.Mints))
+	for i := range expected.Mints {
+		assert.Equal(t, expected.Mints[i].Active, run.Mints[i].Active, "mint %d", i+1)
+		assert.Equal(t, expected.Mints[i].Paid, run.Mints[i].Paid, "mint %d", i+1)
+		assert.Equal(t, expected.Mints[i].Amount, run.Mints[i].Amount, "mint %d", i+1)
+	}
+	assert.Equal(t, expected.Fairness(), run.Fairness())
+}
@@ -0,0 +1,255 @@
+package test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/model"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+var (
+	fuzzSeed  = flag.Int64("fuzz-seed", 1, "seed of the first random sequence of TestFuzzWemix")
+	fuzzRuns  = flag.Int("fuzz-runs", 100, "number of random sequences of TestFuzzWemix, with seeds following -fuzz-seed")
+	fuzzSteps = flag.Int("fuzz-steps", 50, "number of actions of each random sequence of TestFuzzWemix")
+)
+
+//fuzzShortRuns caps -fuzz-runs with -short, for a quick check of 400 actions instead of 5000 by default.
+const fuzzShortRuns = 10
+
+const fuzzSenders = 4
+
+//fuzzer runs sequences of actions on a fresh deployment of the contract and the model together.
+//Without a template only the model runs, with blocks counted like the simulated backend.
+type fuzzer struct {
+	template        *backend.Contract
+	keys            []*ecdsa.PrivateKey //senders, and the first one is the owner
+	ecoFund, wemix  common.Address
+	contractAddress common.Address //the same for every deployment by the first key
+	blockDeployed   *big.Int
+	addresses       []common.Address //senders, ecoFund, wemix, the contract and the zero address
+	contract        *backend.Contract
+	model           *model.Token
+	block           *big.Int //current block of the model only run
+}
+
+func newFuzzer(t *testing.T, template *backend.Contract) *fuzzer {
+	p := &fuzzer{
+		template:      template,
+		ecoFund:       crypto.PubkeyToAddress(seedKey(t, "fuzz ecoFund").PublicKey),
+		wemix:         crypto.PubkeyToAddress(seedKey(t, "fuzz wemix").PublicKey),
+		blockDeployed: big.NewInt(1),
+	}
+	for i := 0; i < fuzzSenders; i++ {
+		p.keys = append(p.keys, seedKey(t, fmt.Sprintf("fuzz sender%d", i)))
+		p.addresses = append(p.addresses, crypto.PubkeyToAddress(p.keys[i].PublicKey))
+	}
+	p.contractAddress = crypto.CreateAddress(p.addresses[0], 0)
+	p.addresses = append(p.addresses, p.ecoFund, p.wemix, p.contractAddress, common.Address{})
+	return p
+}
+
+//reset deploys the contract again with the invariants of WemixToken, and makes the model of the deployment.
+func (p *fuzzer) reset() error {
+	if p.template != nil {
+		p.contract = p.template.Clone()
+		p.contract.OwnerKey, p.contract.Owner = p.keys[0], p.addresses[0]
+		p.contract.GasReport, p.contract.Coverage, p.contract.GasProfile = nil, nil, nil
+		p.contract.Invariants = wemix.Invariants()
+		if err := p.contract.Deploy(p.ecoFund, p.wemix); err != nil {
+			return err
+		}
+		p.blockDeployed = p.contract.BlockDeployed
+	}
+	p.block = new(big.Int).Set(p.blockDeployed)
+	p.model = model.NewToken(p.contractAddress, p.addresses[0], p.blockDeployed, p.ecoFund, p.wemix)
+	return nil
+}
+
+//run executes the actions and returns the first difference between the contract and the model.
+func (p *fuzzer) run(actions []model.Action) error {
+	if err := p.reset(); err != nil {
+		return err
+	}
+	for i, a := range actions {
+		if err := p.step(a); err != nil {
+			return fmt.Errorf("action %d (%v): %w", i, a, err)
+		}
+	}
+	return nil
+}
+
+func (p *fuzzer) step(a model.Action) error {
+	sender, method, args := a.Call(p.model, p.addresses, fuzzSenders)
+	if method == model.Advance {
+		target := new(big.Int).Add(p.block, a.Amount)
+		if a.Amount.Sign() == 0 {
+			//the next transaction is in blockToMint
+			target.Sub(p.model.BlockToMint, common.Big1)
+		}
+		if p.contract != nil {
+			commitUntil(p.contract, target)
+		}
+		if target.Cmp(p.block) > 0 {
+			p.block = target
+		}
+		return nil
+	}
+
+	p.block = new(big.Int).Add(p.block, common.Big1)
+	success := true
+	if p.contract != nil {
+		r, err := p.contract.Execute(p.keys[sender], method, args...)
+		if err != nil {
+			return err
+		}
+		p.block, success = r.BlockNumber, r.Status == 1
+	}
+	err := p.model.Call(p.addresses[sender], p.block, method, args...)
+	if err != nil && errors.Is(err, model.ErrRevert) == false {
+		return err
+	}
+	if p.contract == nil {
+		return nil
+	}
+	if success != (err == nil) {
+		return fmt.Errorf("%s by %s: contract success %v, model error %v", method, p.addresses[sender].Hex(), success, err)
+	}
+	return p.compare()
+}
+
+//compare reads balances, allowances, partners and parameters of the contract, and compares them with the model.
+func (p *fuzzer) compare() error {
+	diffs := []string{}
+	check := func(name string, expected, actual interface{}) {
+		if e, a := fmt.Sprint(expected), fmt.Sprint(actual); e != a {
+			diffs = append(diffs, fmt.Sprintf("%s: model %s, contract %s", name, e, a))
+		}
+	}
+	call := func(method string, args ...interface{}) interface{} {
+		ret, err := p.contract.LowCall(method, args...)
+		if err != nil {
+			diffs = append(diffs, fmt.Sprintf("%s%v: %v", method, args, err))
+			return nil
+		}
+		return ret[0]
+	}
+
+	for _, address := range p.addresses {
+		check("balanceOf "+address.Hex(), p.model.BalanceOf(address), call("balanceOf", address))
+		check("allowedPartners "+address.Hex(), p.model.Allowed[address], call("allowedPartners", address))
+	}
+	for _, owner := range p.addresses[:fuzzSenders] {
+		for _, spender := range p.addresses {
+			check("allowance "+owner.Hex()+" "+spender.Hex(), p.model.Allowance(owner, spender), call("allowance", owner, spender))
+		}
+	}
+
+	for method, expected := range map[string]interface{}{
+		"totalSupply":               p.model.TotalSupply,
+		"owner":                     p.model.Owner,
+		"unitStaking":               p.model.UnitStaking,
+		"minBlockWaitingWithdrawal": p.model.MinBlockWaitingWithdrawal,
+		"ecoFund":                   p.model.EcoFund,
+		"wemix":                     p.model.Wemix,
+		"blockUnitForMint":          p.model.BlockUnitForMint,
+		"mintToPartner":             p.model.MintToPartner,
+		"mintToWemix":               p.model.MintToWemix,
+		"mintToEcoFund":             p.model.MintToEcoFund,
+		"blockToMint":               p.model.BlockToMint,
+		"nextPartnerToMint":         p.model.NextPartnerToMint,
+		"partnersNumber":            len(p.model.Partners),
+	} {
+		check(method, expected, call(method))
+	}
+	value, err := p.contract.Backend.StorageAt(context.Background(), p.contract.Address, wemix.NextBlockUnitForMintSlot, nil)
+	if err != nil {
+		return err
+	}
+	check("nextBlockUnitForMint", p.model.NextBlockUnitForMint, new(big.Int).SetBytes(value))
+
+	for i, expected := range p.model.Partners {
+		actual := wemix.Partner{}
+		if err := p.contract.Call(&actual, "partnerByIndex", big.NewInt(int64(i))); err != nil {
+			return err
+		}
+		check(fmt.Sprintf("partnerByIndex %d", i), model.Partner(actual), expected)
+	}
+
+	if len(diffs) > 0 {
+		return errors.New(strings.Join(diffs, "\n"))
+	}
+	return nil
+}
+
+//shrink returns the minimal sequence of the actions failing on the fuzzer.
+func (p *fuzzer) shrink(actions []model.Action) []model.Action {
+	return model.Shrink(actions, func(a []model.Action) bool { return p.run(a) != nil })
+}
+
+func formatActions(actions []model.Action) string {
+	lines := []string{}
+	for _, a := range actions {
+		lines = append(lines, "  "+a.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+//Run random sequences of actions on the contract and the model, and compare their whole state after every step.
+//A failing sequence is shrunk to a minimal one, which runs again with its seed:
+//go test -run TestFuzzWemix -fuzz-seed <seed> -fuzz-runs 1
+func TestFuzzWemix(t *testing.T) {
+	template, err := backend.NewContract("../contracts/WemixToken.sol", "WemixToken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newFuzzer(t, template)
+	runs := *fuzzRuns
+	if testing.Short() && runs > fuzzShortRuns {
+		runs = fuzzShortRuns
+	}
+	for i := 0; i < runs; i++ {
+		seed := *fuzzSeed + int64(i)
+		actions := model.RandomActions(rand.New(rand.NewSource(seed)), *fuzzSteps, fuzzSenders, len(p.addresses))
+		if err := p.run(actions); err != nil {
+			shrunk := p.shrink(actions)
+			t.Fatalf("seed %d: %v\nminimal sequence of %d actions out of %d, senders are @0-@%d:\n%s\n%v",
+				seed, err, len(shrunk), len(actions), fuzzSenders-1, formatActions(shrunk), p.run(shrunk))
+		}
+	}
+	t.Logf("%d sequences of %d actions from seed %d", runs, *fuzzSteps, *fuzzSeed)
+}
+
+//Test the shrinker with the model only, against a sequence failing when a partner is staked.
+func TestFuzzShrink(t *testing.T) {
+	p := newFuzzer(t, nil)
+	staked := func(actions []model.Action) bool {
+		assert.NoError(t, p.run(actions))
+		return len(p.model.Partners) > 0
+	}
+
+	actions := []model.Action(nil)
+	for seed := int64(1); staked(actions) == false; seed++ {
+		actions = model.RandomActions(rand.New(rand.NewSource(seed)), 100, fuzzSenders, len(p.addresses))
+	}
+	shrunk := model.Shrink(actions, staked)
+	t.Logf("%d actions out of %d:\n%s", len(shrunk), len(actions), formatActions(shrunk))
+	assert.True(t, staked(shrunk))
+	//addAllowedPartner and a stake, with a transfer or change_unitStaking if a sender without tokens stakes
+	assert.True(t, len(shrunk) <= 3)
+	for i := range shrunk {
+		assert.False(t, staked(append(append([]model.Action{}, shrunk[:i]...), shrunk[i+1:]...)), shrunk[i].String())
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+//Test to record gas used per method.
+func TestWemixGasReport(t *testing.T) {
+	contract := depolyWemix(t)
+	report := backend.NewGasReport()
+	contract.GasReport = report
+
+	key, _ := crypto.GenerateKey()
+	to := crypto.PubkeyToAddress(key.PublicKey)
+	expectedSuccess(t, contract, nil, "transfer", to, big.NewInt(1))
+	expectedSuccess(t, contract, nil, "transfer", to, big.NewInt(2))
+	expectedFail(t, contract, key, "transfer", contract.Owner, big.NewInt(100))
+
+	stats := report.Stats()
+	assert.Equal(t, 1, len(stats))
+	s := stats[0]
+	assert.Equal(t, "WemixToken", s.Contract)
+	assert.Equal(t, "transfer", s.Method)
+	assert.Equal(t, 2, s.Count)
+	assert.Equal(t, 1, s.Reverted)
+	assert.True(t, s.Min > 21000 && s.Min <= s.Mean() && s.Mean() <= s.Max)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.Print(&buf))
+	assert.Contains(t, buf.String(), "transfer")
+	t.Log("\n" + buf.String())
+}
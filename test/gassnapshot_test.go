@@ -0,0 +1,183 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+const gasSnapshotFile = "../.gas-snapshot"
+
+var (
+	updateGasSnapshot = flag.Bool("update-gas-snapshot", false, "regenerate "+gasSnapshotFile+" from the scenarios")
+	gasTolerance      = flag.Float64("gas-tolerance", 0, "percent of gas change allowed against "+gasSnapshotFile)
+)
+
+//depolyWemixSeeded deploys the contract with addresses derived from seeds,
+//so that gas used does not change with zero bytes of random addresses.
+func depolyWemixSeeded(t *testing.T) *backend.Contract {
+	contract, err := backend.NewContract("../contracts/WemixToken.sol", "WemixToken")
+	assert.NoError(t, err)
+	contract.GasReport = nil
+
+	if err := contract.Deploy(
+		crypto.PubkeyToAddress(seedKey(t, "ecoFund").PublicKey),
+		crypto.PubkeyToAddress(seedKey(t, "wemix").PublicKey),
+	); err != nil {
+		assert.NoError(t, err)
+	}
+	return contract
+}
+
+//stakeSeeded registers n partners delegated by the owner, and returns their serials.
+func stakeSeeded(t *testing.T, contract *backend.Contract, n int) []*big.Int {
+	serials := []*big.Int{}
+	for i := 0; i < n; i++ {
+		partner := crypto.PubkeyToAddress(seedKey(t, fmt.Sprintf("partner%d", i)).PublicKey)
+		expectedSuccess(t, contract, nil, "addAllowedPartner", partner)
+
+		r, err := contract.Execute(nil, "stakeDelegated", partner, new(big.Int))
+		assert.NoError(t, err)
+		assert.True(t, r.Status == 1)
+		for _, g := range r.Logs {
+			if g.Topics[0] == contract.Abi.Events["Staked"].ID {
+				serials = append(serials, g.Topics[3].Big())
+			}
+		}
+	}
+	return serials
+}
+
+//mintGas makes blocks until mint is possible, and returns gas used by mint.
+func mintGas(t *testing.T, contract *backend.Contract) uint64 {
+	blockToMint := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&blockToMint, "blockToMint"))
+	commitUntil(contract, blockToMint)
+	return executeGas(t, contract, nil, "mint")
+}
+
+//withdrawGas waits for the stake of the serial to be withdrawable, and returns gas used by withdraw.
+func withdrawGas(t *testing.T, contract *backend.Contract, serial *big.Int) uint64 {
+	p := wemix.Partner{}
+	assert.NoError(t, contract.Call(&p, "partnerBySerial", serial))
+	commitUntil(contract, p.UnlockBlock())
+	return executeGas(t, contract, nil, "withdraw", serial)
+}
+
+//measureGasScenarios runs the scenarios and returns gas used by each.
+func measureGasScenarios(t *testing.T) backend.GasSnapshot {
+	ret := backend.GasSnapshot{}
+	other := crypto.PubkeyToAddress(seedKey(t, "other").PublicKey)
+
+	contract := depolyWemixSeeded(t)
+	receipt, err := contract.Backend.TransactionReceipt(context.Background(), contract.Backend.Blockchain().CurrentBlock().Transactions()[0].Hash())
+	assert.NoError(t, err)
+	ret["deploy"] = receipt.GasUsed
+	ret["transfer to new holder"] = executeGas(t, contract, nil, "transfer", other, big.NewInt(1))
+	ret["transfer to holder"] = executeGas(t, contract, nil, "transfer", other, big.NewInt(1))
+	ret["approve"] = executeGas(t, contract, nil, "approve", other, big.NewInt(1))
+	ret["addAllowedPartner"] = executeGas(t, contract, nil, "addAllowedPartner", other)
+	ret["removeAllowedPartner"] = executeGas(t, contract, nil, "removeAllowedPartner", other)
+	ret["mint with 0 partners"] = mintGas(t, contract)
+	ret["change_blockUnitForMint"] = executeGas(t, contract, nil, "change_blockUnitForMint", big.NewInt(60))
+	ret["mint applying blockUnitForMint"] = mintGas(t, contract)
+
+	//stake
+	contract = depolyWemixSeeded(t)
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", big.NewInt(10))
+	partnerKey := seedKey(t, "staker")
+	partner := crypto.PubkeyToAddress(partnerKey.PublicKey)
+	unitStaking := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&unitStaking, "unitStaking"))
+	expectedSuccess(t, contract, nil, "transfer", partner, unitStaking)
+	expectedSuccess(t, contract, nil, "addAllowedPartner", partner)
+	ret["stake"] = executeGas(t, contract, partnerKey, "stake", new(big.Int))
+	expectedSuccess(t, contract, nil, "addAllowedPartner", other)
+	ret["stakeDelegated"] = executeGas(t, contract, nil, "stakeDelegated", other, new(big.Int))
+	ret["mint with 2 partners"] = mintGas(t, contract)
+
+	//withdraw from 10 partners
+	contract = depolyWemixSeeded(t)
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", big.NewInt(10))
+	serials := stakeSeeded(t, contract, 10)
+	ret["withdraw last partner"] = withdrawGas(t, contract, serials[9])
+	ret["withdraw middle partner"] = withdrawGas(t, contract, serials[4])
+	ret["withdraw first partner"] = withdrawGas(t, contract, serials[0])
+
+	//mint with 100 partners
+	contract = depolyWemixSeeded(t)
+	stakeSeeded(t, contract, 100)
+	ret["mint with 100 partners"] = mintGas(t, contract)
+	for i := 1; i < 100; i++ {
+		mintGas(t, contract)
+	}
+	ret["mint with 100 partners wrapping around"] = mintGas(t, contract)
+
+	return ret
+}
+
+//Test to compare gas used by scenarios with the snapshot file.
+//Run with -update-gas-snapshot to regenerate the file, and -gas-tolerance to allow changes in percent.
+//It is skipped until the file records scenarios, and then a scenario missing from the file fails.
+func TestGasSnapshot(t *testing.T) {
+	current := measureGasScenarios(t)
+	if t.Failed() {
+		return
+	}
+
+	if *updateGasSnapshot {
+		assert.NoError(t, current.Save(gasSnapshotFile))
+		t.Logf("ok > %s updated, %d scenarios", gasSnapshotFile, len(current))
+		return
+	}
+
+	snapshot, err := backend.LoadGasSnapshot(gasSnapshotFile)
+	assert.NoError(t, err)
+	if len(snapshot) == 0 {
+		t.Skipf("%s has no scenarios, run with -update-gas-snapshot to record %d", gasSnapshotFile, len(current))
+	}
+
+	for _, d := range snapshot.Compare(current, *gasTolerance) {
+		if d.Exceeded {
+			t.Errorf("gas changed over %.2f%%: %s", *gasTolerance, d)
+		} else {
+			t.Log(d)
+		}
+	}
+	for name, gas := range current {
+		if _, ok := snapshot[name]; ok == false {
+			t.Errorf("%s (gas: %d) is not in %s, run with -update-gas-snapshot", name, gas, gasSnapshotFile)
+		}
+	}
+}
+
+//Test to check the regression detection of the snapshot.
+func TestGasSnapshotCompare(t *testing.T) {
+	old := backend.GasSnapshot{"a": 1000, "b": 1000, "c": 1000}
+	current := backend.GasSnapshot{"a": 1000, "b": 1020, "d": 500}
+
+	diffs := old.Compare(current, 1)
+	assert.Equal(t, 3, len(diffs))
+	assert.Equal(t, "b", diffs[0].Name)
+	assert.True(t, diffs[0].Exceeded)
+	assert.Equal(t, "c", diffs[1].Name)
+	assert.False(t, diffs[1].Exceeded)
+	assert.Equal(t, "d", diffs[2].Name)
+	assert.False(t, diffs[2].Exceeded)
+
+	assert.False(t, old.Compare(current, 2)[0].Exceeded)
+
+	file := t.TempDir() + "/.gas-snapshot"
+	assert.NoError(t, current.Save(file))
+	loaded, err := backend.LoadGasSnapshot(file)
+	assert.NoError(t, err)
+	assert.Equal(t, current, loaded)
+}
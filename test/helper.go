@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/wemade-tree/wemix-token/backend"
 )
 
@@ -72,3 +73,33 @@ func expectedSuccess(t *testing.T, contract *backend.Contract, key *ecdsa.Privat
 	assert.NoError(t, err)
 	assert.True(t, r.Status == 1)
 }
+
+//checkStorage compares a value read directly from the contract storage with a given expected value.
+func checkStorage(t *testing.T, contract *backend.Contract, expected interface{}, label string, keys ...interface{}) {
+	ret, err := contract.ReadStorage(nil, label, keys...)
+	assert.NoError(t, err)
+	assert.Equal(t, toBytes(t, ret), toBytes(t, expected))
+	t.Log("storage", label, keys, ret)
+}
+
+//seedKey returns a private key derived from the seed, to make addresses the same on every run.
+func seedKey(t *testing.T, seed string) *ecdsa.PrivateKey {
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte(seed)))
+	assert.NoError(t, err)
+	return key
+}
+
+//executeGas executes the method successfully and returns gas used.
+func executeGas(t *testing.T, contract *backend.Contract, key *ecdsa.PrivateKey, method string, arg ...interface{}) uint64 {
+	r, err := contract.Execute(key, method, arg...)
+	assert.NoError(t, err)
+	assert.True(t, r.Status == 1)
+	return r.GasUsed
+}
+
+//commitUntil makes blocks until the current block reaches the given block.
+func commitUntil(contract *backend.Contract, block *big.Int) {
+	for contract.Backend.Blockchain().CurrentBlock().Header().Number.Cmp(block) < 0 {
+		contract.Backend.Commit()
+	}
+}
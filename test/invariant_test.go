@@ -0,0 +1,69 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test the invariants of WemixToken through stakes, mints and withdrawals,
+//and the report of a transaction violating an invariant.
+func TestWemixInvariants(t *testing.T) {
+	contract := depolyWemix(t)
+	contract.Invariants = wemix.Invariants()
+	name, err := contract.CheckInvariants()
+	assert.NoError(t, err, name)
+
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", big.NewInt(1))
+	serials := stakeSeeded(t, contract, 3)
+	for i := 0; i < 4; i++ {
+		blockToMint := (*big.Int)(nil)
+		assert.NoError(t, contract.Call(&blockToMint, "blockToMint"))
+		commitUntil(contract, blockToMint)
+		expectedSuccess(t, contract, nil, "mint")
+	}
+	expectedSuccess(t, contract, nil, "withdraw", serials[0])
+	other := crypto.PubkeyToAddress(seedKey(t, "other").PublicKey)
+	expectedSuccess(t, contract, nil, "transfer", other, big.NewInt(1))
+	assert.Nil(t, contract.Violation)
+
+	//an invariant which the next transaction breaks
+	unit := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&unit, "unitStaking"))
+	contract.AddInvariant("unitStaking is constant", func(c *backend.Contract) error {
+		v := (*big.Int)(nil)
+		if err := c.Call(&v, "unitStaking"); err != nil {
+			return err
+		}
+		if v.Cmp(unit) != 0 {
+			return fmt.Errorf("unitStaking %v, expected %v", v, unit)
+		}
+		return nil
+	})
+	expectedSuccess(t, contract, nil, "change_mintToPartner", big.NewInt(1))
+
+	r, err := contract.Execute(nil, "change_unitStaking", big.NewInt(7))
+	assert.True(t, r.Status == 1)
+	violation := (*backend.InvariantViolation)(nil)
+	assert.True(t, errors.As(err, &violation))
+	t.Log(violation)
+	assert.Equal(t, "unitStaking is constant", violation.Invariant)
+	assert.Equal(t, "change_unitStaking", violation.Method)
+	assert.Equal(t, r.BlockNumber, violation.Block)
+	assert.Equal(t, 1, len(violation.Diff))
+	assert.Equal(t, []string{"unitStaking"}, violation.Diff[0].Labels)
+	assert.Equal(t, unit, violation.Diff[0].Old.Big())
+	assert.Equal(t, big.NewInt(7), violation.Diff[0].New.Big())
+
+	//the first violation is kept
+	_, err = contract.Execute(nil, "change_unitStaking", big.NewInt(8))
+	assert.Error(t, err)
+	assert.Equal(t, violation, contract.Violation)
+}
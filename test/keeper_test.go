@@ -0,0 +1,190 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func newKeeper(t *testing.T, contract *backend.Contract, seed string) *wemix.Keeper {
+	key := contract.OwnerKey
+	if seed != "" {
+		key = seedKey(t, seed)
+	}
+	client, err := wemix.NewClient(contract.Address, contract.Backend, key)
+	assert.NoError(t, err)
+	keeper, err := wemix.NewKeeper(client)
+	assert.NoError(t, err)
+	keeper.StaleAfter = time.Minute
+	return keeper
+}
+
+func blockToMint(t *testing.T, contract *backend.Contract) *big.Int {
+	v := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&v, "blockToMint"))
+	return v
+}
+
+//Test two keepers racing for every mint in the same block: one mints, and the call of the other reverts.
+func TestKeeperRace(t *testing.T) {
+	contract := depolyWemix(t)
+	stakeSeeded(t, contract, 2)
+	ctx := context.Background()
+	first, second := newKeeper(t, contract, ""), newKeeper(t, contract, "keeper2")
+
+	start := blockToMint(t, contract)
+	last := new(big.Int).Add(start, big.NewInt(180))
+	for contract.Backend.Blockchain().CurrentBlock().Number().Cmp(last) < 0 {
+		assert.NoError(t, first.Poll(ctx))
+		assert.NoError(t, second.Poll(ctx))
+		contract.Backend.Commit()
+	}
+	assert.NoError(t, first.Poll(ctx))
+	assert.NoError(t, second.Poll(ctx))
+
+	//mints at start, +60, +120 and +180
+	assert.Equal(t, new(big.Int).Add(start, big.NewInt(240)), blockToMint(t, contract))
+	a, b := first.Health(), second.Health()
+	t.Logf("%+v\n%+v", a, b)
+	assert.Equal(t, uint64(4), a.Minted+b.Minted)
+	assert.Equal(t, uint64(4), a.Lost+b.Lost)
+	assert.Equal(t, uint64(8), a.Sent+b.Sent)
+	assert.Equal(t, uint64(0), a.Errors+b.Errors)
+	assert.Equal(t, 0, a.Pending+b.Pending)
+	assert.True(t, a.Healthy && b.Healthy)
+}
+
+//Test a keeper catching up a backlog with calls of consecutive nonces in the same block.
+func TestKeeperCatchUp(t *testing.T) {
+	contract := depolyWemix(t)
+	stakeSeeded(t, contract, 3)
+	ctx := context.Background()
+	start := blockToMint(t, contract)
+	commitUntil(contract, new(big.Int).Add(start, big.NewInt(300)))
+
+	keeper := newKeeper(t, contract, "")
+	keeper.MaxPending = 4
+	assert.NoError(t, keeper.Poll(ctx))
+	health := keeper.Health()
+	assert.Equal(t, uint64(6), health.Backlog) //301/60 + 1
+	assert.Equal(t, 4, health.Pending)
+	contract.Backend.Commit()
+
+	assert.NoError(t, keeper.Poll(ctx))
+	health = keeper.Health()
+	assert.Equal(t, uint64(4), health.Minted)
+	assert.Equal(t, uint64(2), health.Backlog)
+	contract.Backend.Commit()
+
+	assert.NoError(t, keeper.Poll(ctx))
+	health = keeper.Health()
+	assert.Equal(t, uint64(6), health.Minted)
+	assert.Equal(t, uint64(6), health.Sent)
+	assert.Equal(t, uint64(0), health.Backlog)
+	assert.Equal(t, new(big.Int).Add(start, big.NewInt(360)), blockToMint(t, contract))
+
+	//every partner was paid twice, 0.5*60 each
+	for _, serial := range []int64{1, 2, 3} {
+		partner := wemix.Partner{}
+		assert.NoError(t, contract.Call(&partner, "partnerBySerial", big.NewInt(serial)))
+		balance := (*big.Int)(nil)
+		assert.NoError(t, contract.Call(&balance, "balanceOf", partner.Partner))
+		assert.Equal(t, toBig(t, "60000000000000000000"), balance)
+	}
+}
+
+//Test a keeper whose calls are dropped: the call pending is stuck after the timeout, and dropped once its nonce is used by another transaction.
+func TestKeeperDropped(t *testing.T) {
+	contract := depolyWemix(t)
+	stakeSeeded(t, contract, 1)
+	ctx := context.Background()
+	start := blockToMint(t, contract)
+	commitUntil(contract, start)
+
+	client, err := wemix.NewClient(contract.Address, lostBackend{contract.Backend}, contract.OwnerKey)
+	assert.NoError(t, err)
+	keeper, err := wemix.NewKeeper(client)
+	assert.NoError(t, err)
+	keeper.StaleAfter, keeper.PendingTimeout = time.Minute, time.Hour
+
+	assert.NoError(t, keeper.Poll(ctx))
+	health := keeper.Health()
+	assert.Equal(t, uint64(1), health.Sent)
+	assert.Equal(t, 1, health.Pending)
+	assert.Equal(t, 0, health.Stuck)
+	assert.True(t, health.Healthy)
+
+	keeper.PendingTimeout = time.Nanosecond
+	contract.Backend.Commit()
+	assert.NoError(t, keeper.Poll(ctx))
+	health = keeper.Health()
+	assert.Equal(t, uint64(1), health.Sent) //no more calls while one is pending
+	assert.Equal(t, uint64(1), health.Resent)
+	assert.Equal(t, 1, health.Stuck)
+	assert.False(t, health.Healthy)
+	assert.Equal(t, start, blockToMint(t, contract))
+
+	//the owner mints with the nonce of the call dropped
+	expectedSuccess(t, contract, nil, "mint")
+	assert.NoError(t, keeper.Poll(ctx))
+	health = keeper.Health()
+	assert.Equal(t, uint64(1), health.Dropped)
+	assert.Equal(t, uint64(0), health.Minted)
+	assert.Equal(t, 0, health.Pending)
+	assert.Equal(t, 0, health.Stuck)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, new(big.Int).Add(start, big.NewInt(60)), blockToMint(t, contract))
+}
+
+//Test the loop of a keeper while blocks are made, and its health over HTTP.
+func TestKeeperRun(t *testing.T) {
+	contract := depolyWemix(t)
+	stakeSeeded(t, contract, 1)
+	keeper := newKeeper(t, contract, "")
+	keeper.Interval = 5 * time.Millisecond
+
+	server := httptest.NewServer(keeper)
+	defer server.Close()
+	res, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode) //not polled yet
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- keeper.Run(ctx) }()
+
+	target := new(big.Int).Add(blockToMint(t, contract), big.NewInt(120))
+	deadline := time.Now().Add(time.Minute)
+	for blockToMint(t, contract).Cmp(target) < 0 && time.Now().Before(deadline) {
+		contract.Backend.Commit()
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	assert.NoError(t, <-done)
+	assert.True(t, blockToMint(t, contract).Cmp(target) >= 0)
+
+	//a poll may fail when a block is made in the middle of it, since the simulated backend only calls at the latest block
+	assert.NoError(t, keeper.Poll(context.Background()))
+
+	res, err = http.Get(server.URL)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	health := wemix.KeeperHealth{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&health))
+	assert.True(t, health.Healthy)
+	assert.True(t, health.Minted >= 2)
+
+	_, err = wemix.NewKeeper(&wemix.Client{})
+	assert.Error(t, err)
+}
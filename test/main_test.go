@@ -0,0 +1,85 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+var (
+	solCoverage = flag.String("sol-coverage", "", "write lcov.info and index.html of solidity coverage to the directory")
+	gasProfile  = flag.String("gas-profile", "", "write gas used by solidity functions to the file in the folded stack format")
+)
+
+//Print gas used by each contract method after all tests,
+//and write solidity coverage and gas profile if they are requested.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *solCoverage != "" {
+		backend.DefaultCoverage = backend.NewCoverage()
+	}
+	if *gasProfile != "" {
+		backend.DefaultGasProfile = backend.NewGasProfile()
+		backend.DefaultGasProfile.LineFrames = true
+	}
+
+	code := m.Run()
+	backend.DefaultGasReport.Print(os.Stdout)
+
+	if *solCoverage != "" {
+		if err := writeCoverage(*solCoverage, backend.DefaultCoverage); err != nil {
+			fmt.Fprintln(os.Stderr, "solidity coverage:", err)
+			code = 1
+		}
+	}
+	if *gasProfile != "" {
+		if err := writeGasProfile(*gasProfile, backend.DefaultGasProfile); err != nil {
+			fmt.Fprintln(os.Stderr, "gas profile:", err)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+func writeCoverage(dir string, coverage *backend.Coverage) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, write := range map[string]func(*os.File) error{
+		"lcov.info":  func(f *os.File) error { return coverage.WriteLCOV(f) },
+		"index.html": func(f *os.File) error { return coverage.WriteHTML(f) },
+	} {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := write(f); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	for _, s := range coverage.Summary() {
+		fmt.Printf("%s: lines %.1f%% (%d/%d), branches %.1f%% (%d/%d)\n", s.File,
+			s.LinePercent(), s.LinesHit, s.LinesFound, s.BranchPercent(), s.BranchesHit, s.BranchesFound)
+	}
+	return nil
+}
+
+func writeGasProfile(file string, profile *backend.GasProfile) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := profile.WriteFolded(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,190 @@
+package test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/model"
+)
+
+//Test the mint model on its own.
+func TestMintModel(t *testing.T) {
+	wemix, ecoFund := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	a, b, c := common.HexToAddress("0x0a"), common.HexToAddress("0x0b"), common.HexToAddress("0x0c")
+	m := model.NewMint(big.NewInt(1), ecoFund, wemix)
+	assert.Equal(t, big.NewInt(61), m.BlockToMint)
+
+	//not mintable yet
+	_, err := m.Run([]model.Event{model.MintEvent{Block: big.NewInt(60)}})
+	assert.True(t, errors.Is(err, model.ErrNotMintable))
+
+	//no partner mints nothing, but advances blockToMint
+	r, err := m.Run([]model.Event{model.MintEvent{Block: big.NewInt(61)}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(r.Emissions))
+	assert.Equal(t, big.NewInt(121), m.BlockToMint)
+
+	r, err = m.Run([]model.Event{
+		model.StakeEvent{Partner: model.Partner{Serial: big.NewInt(1), Partner: a}},
+		model.StakeEvent{Partner: model.Partner{Serial: big.NewInt(2), Partner: b}},
+		model.StakeEvent{Partner: model.Partner{Serial: big.NewInt(3), Partner: c}},
+		model.MintEvent{Block: big.NewInt(121)}, //a
+		model.MintEvent{Block: big.NewInt(181)}, //b
+		model.BlockUnitEvent{Value: big.NewInt(10)},
+		model.MintEvent{Block: big.NewInt(241)}, //c, and then the unit is 10
+		model.MintEvent{Block: big.NewInt(251)}, //a after wraparound
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(261), m.BlockToMint)
+	assert.Equal(t, big.NewInt(10), m.BlockUnitForMint)
+	assert.Equal(t, 0, m.NextBlockUnitForMint.Sign())
+	assert.Equal(t, uint64(1), m.NextPartnerToMint)
+	assert.Equal(t, toBig(t, "35000000000000000000"), r.ByAddress[a]) //0.5*60 + 0.5*10
+	assert.Equal(t, toBig(t, "30000000000000000000"), r.ByAddress[b])
+	assert.Equal(t, toBig(t, "47500000000000000000"), r.ByAddress[wemix]) //0.25*60*3 + 0.25*10
+	assert.Equal(t, toBig(t, "190000000000000000000"), r.Total)
+
+	//withdrawing a moves c into index 0, and b at index 1 is next
+	assert.NoError(t, m.Withdraw(big.NewInt(1)))
+	r, err = m.Run([]model.Event{model.MintEvent{Block: big.NewInt(261)}})
+	assert.NoError(t, err)
+	assert.Equal(t, b, r.Emissions[0].To)
+	assert.True(t, errors.Is(m.Withdraw(big.NewInt(1)), model.ErrPartnerNotFound))
+}
+
+//mintModelRunner executes events on the contract and the model together.
+type mintModelRunner struct {
+	t        *testing.T
+	contract *backend.Contract
+	model    *model.Mint
+	initial  map[common.Address]*big.Int
+	minted   map[common.Address]*big.Int
+	supply   *big.Int
+}
+
+func (p *mintModelRunner) balanceOf(address common.Address) *big.Int {
+	b := (*big.Int)(nil)
+	assert.NoError(p.t, p.contract.Call(&b, "balanceOf", address))
+	return b
+}
+
+//watch remembers the balance of the address before any mint.
+func (p *mintModelRunner) watch(address common.Address) {
+	if _, ok := p.initial[address]; ok == false {
+		p.initial[address] = p.balanceOf(address)
+		p.minted[address] = new(big.Int)
+	}
+}
+
+func (p *mintModelRunner) stake(partner common.Address) {
+	r, err := p.contract.Execute(nil, "addAllowedPartner", partner)
+	assert.NoError(p.t, err)
+	assert.True(p.t, r.Status == 1)
+	r, err = p.contract.Execute(nil, "stakeDelegated", partner, new(big.Int))
+	assert.NoError(p.t, err)
+	assert.True(p.t, r.Status == 1)
+	serial := r.Logs[len(r.Logs)-1].Topics[3].Big()
+	p.watch(partner)
+	p.apply(model.StakeEvent{Partner: model.Partner{Serial: serial, Partner: partner, Payer: p.contract.Owner}})
+}
+
+func (p *mintModelRunner) withdraw(serial *big.Int) {
+	expectedSuccess(p.t, p.contract, nil, "withdraw", serial)
+	p.apply(model.WithdrawEvent{Serial: serial})
+}
+
+func (p *mintModelRunner) mint() {
+	commitUntil(p.contract, p.model.BlockToMint)
+	r, err := p.contract.Execute(nil, "mint")
+	assert.NoError(p.t, err)
+	assert.True(p.t, r.Status == 1)
+	p.apply(model.MintEvent{Block: r.BlockNumber})
+	p.check()
+}
+
+func (p *mintModelRunner) apply(e model.Event) {
+	emissions, err := e.Apply(p.model)
+	assert.NoError(p.t, err)
+	for _, emission := range emissions {
+		p.minted[emission.To].Add(p.minted[emission.To], emission.Amount)
+		p.supply.Add(p.supply, emission.Amount)
+	}
+}
+
+//check compares balances, the total supply and the mint state of the contract with the model.
+func (p *mintModelRunner) check() {
+	for address, initial := range p.initial {
+		assert.Equal(p.t, new(big.Int).Add(initial, p.minted[address]), p.balanceOf(address), address.Hex())
+	}
+	checkVariable(p.t, p.contract, "totalSupply", p.supply)
+	checkVariable(p.t, p.contract, "blockToMint", p.model.BlockToMint)
+	checkVariable(p.t, p.contract, "blockUnitForMint", p.model.BlockUnitForMint)
+	checkVariable(p.t, p.contract, "nextPartnerToMint", new(big.Int).SetUint64(p.model.NextPartnerToMint))
+	checkStorage(p.t, p.contract, p.model.NextBlockUnitForMint, "nextBlockUnitForMint")
+}
+
+//Test the mint model against the contract with stakes, withdrawals and parameter changes.
+func TestMintModelEVM(t *testing.T) {
+	contract := depolyWemix(t)
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", big.NewInt(1))
+
+	ecoFund, wemix := contract.ConstructorInputs[0].(common.Address), contract.ConstructorInputs[1].(common.Address)
+	supply := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&supply, "totalSupply"))
+	p := &mintModelRunner{
+		t:        t,
+		contract: contract,
+		model:    model.NewMint(contract.BlockDeployed, ecoFund, wemix),
+		initial:  map[common.Address]*big.Int{},
+		minted:   map[common.Address]*big.Int{},
+		supply:   supply,
+	}
+	p.watch(ecoFund)
+	p.watch(wemix)
+
+	//no partner
+	p.mint()
+
+	partners := []common.Address{}
+	for i := 0; i < 4; i++ {
+		key, _ := crypto.GenerateKey()
+		partners = append(partners, crypto.PubkeyToAddress(key.PublicKey))
+		p.stake(partners[i])
+	}
+	for i := 0; i < 6; i++ {
+		p.mint()
+	}
+
+	//the deferred unit applies after the next mint
+	expectedSuccess(t, contract, nil, "change_blockUnitForMint", big.NewInt(7))
+	p.apply(model.BlockUnitEvent{Value: big.NewInt(7)})
+	expectedSuccess(t, contract, nil, "change_mintToPartner", toBig(t, "1000000000000000000"))
+	p.apply(model.RateEvent{Kind: "partner", Value: toBig(t, "1000000000000000000")})
+	p.mint()
+	p.mint()
+
+	//withdrawals reorder partners under the round-robin
+	for _, i := range []int{1, 0} {
+		p.withdraw(p.model.Partners[i].Serial)
+		p.mint()
+	}
+	newWemix := crypto.PubkeyToAddress(seedKey(t, "new wemix").PublicKey)
+	expectedSuccess(t, contract, nil, "change_wemix", newWemix)
+	p.watch(newWemix)
+	p.apply(model.RecipientEvent{Kind: "wemix", Address: newWemix})
+	for i := 0; i < 5; i++ {
+		p.mint()
+	}
+
+	for len(p.model.Partners) > 0 {
+		p.withdraw(p.model.Partners[0].Serial)
+	}
+	p.mint()
+	p.mint()
+}
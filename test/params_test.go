@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test to read all parameters at a block and to diff snapshots.
+func TestWemixParams(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	client, err := wemix.NewClient(contract.Address, contract.Archive(), contract.OwnerKey)
+	assert.NoError(t, err)
+
+	before, err := client.Params(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, contract.Backend.Blockchain().CurrentBlock().Number(), before.Block)
+	assert.Equal(t, toBig(t, "2000000000000000000000000"), before.UnitStaking)
+	assert.Equal(t, big.NewInt(60), before.BlockUnitForMint)
+	assert.Equal(t, contract.Owner, before.Owner)
+	assert.Equal(t, contract.ConstructorInputs[0], before.EcoFund)
+	assert.Equal(t, toBig(t, "1000000000000000000000000000"), before.TotalSupply)
+	assert.Equal(t, 0, before.NextBlockUnitForMint.Sign())
+
+	expectedSuccess(t, contract, nil, "change_blockUnitForMint", big.NewInt(100))
+	expectedSuccess(t, contract, nil, "change_mintToPartner", big.NewInt(1))
+	checkStorage(t, contract, big.NewInt(100), "nextBlockUnitForMint")
+
+	after, err := client.Params(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), after.NextBlockUnitForMint)
+
+	changes := wemix.DiffParams(before, after)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, "MintToPartner", changes[0].Name)
+	assert.Equal(t, big.NewInt(1), changes[0].New)
+	assert.Equal(t, "NextBlockUnitForMint", changes[1].Name)
+	for _, c := range changes {
+		t.Log(c)
+	}
+
+	//the old block still reads the old parameters
+	again, err := client.ParamsAt(ctx, before.Block)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(wemix.DiffParams(before, again)))
+}
+
+//Test DiffParams without a contract.
+func TestWemixDiffParams(t *testing.T) {
+	old := &wemix.Params{
+		Block:       big.NewInt(1),
+		UnitStaking: big.NewInt(10),
+		TotalSupply: big.NewInt(100),
+		Owner:       common.HexToAddress("0x01"),
+	}
+	new := &wemix.Params{
+		Block:       big.NewInt(2),
+		UnitStaking: big.NewInt(10),
+		TotalSupply: big.NewInt(101),
+		Owner:       common.HexToAddress("0x02"),
+	}
+	changes := wemix.DiffParams(old, new)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, "Owner", changes[0].Name)
+	assert.Equal(t, "TotalSupply", changes[1].Name)
+	assert.Equal(t, "TotalSupply: 100 -> 101", changes[1].String())
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test helpers of Partner.
+func TestWemixPartner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	p := wemix.Partner{
+		Serial:                 big.NewInt(1),
+		Partner:                crypto.PubkeyToAddress(key.PublicKey),
+		Payer:                  crypto.PubkeyToAddress(key.PublicKey),
+		BlockStaking:           big.NewInt(100),
+		BlockWaitingWithdrawal: big.NewInt(50),
+		BalanceStaking:         big.NewInt(1),
+	}
+	assert.Equal(t, big.NewInt(150), p.UnlockBlock())
+	assert.False(t, p.IsWithdrawable(big.NewInt(149)))
+	assert.True(t, p.IsWithdrawable(big.NewInt(150)))
+	assert.False(t, p.IsDelegated())
+
+	p.Payer = crypto.PubkeyToAddress(seedKey(t, "payer").PublicKey)
+	assert.True(t, p.IsDelegated())
+}
+
+//Test that the partner iterator reads a consistent snapshot at its block while partners are withdrawn.
+func TestWemixPartnerIterator(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+
+	r, err := contract.Execute(nil, "change_minBlockWaitingWithdrawal", big.NewInt(1))
+	assert.NoError(t, err)
+	assert.True(t, r.Status == 1)
+
+	client, err := wemix.NewClient(contract.Address, contract.Archive(), contract.OwnerKey)
+	assert.NoError(t, err)
+
+	serials := []*big.Int{}
+	for i := 0; i < 5; i++ {
+		key, _ := crypto.GenerateKey()
+		partner := crypto.PubkeyToAddress(key.PublicKey)
+		_, err = client.AllowPartner(ctx, partner)
+		assert.NoError(t, err)
+		serial, err := client.StakeFor(ctx, partner, new(big.Int))
+		assert.NoError(t, err)
+		serials = append(serials, serial)
+	}
+	pinned := contract.Backend.Blockchain().CurrentBlock().Number()
+
+	it, err := client.PartnerIterator(ctx, pinned, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), it.Total())
+
+	//withdrawals between pages reorder the partners at the latest block.
+	pages := 0
+	read := []*big.Int{}
+	for it.Next() {
+		assert.True(t, len(it.Page()) <= 2)
+		for _, p := range it.Page() {
+			assert.True(t, p.IsDelegated())
+			assert.Equal(t, contract.Owner, p.Payer)
+			read = append(read, p.Serial)
+		}
+		if pages < 2 {
+			_, err = client.Withdraw(ctx, serials[pages])
+			assert.NoError(t, err)
+		}
+		pages++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, serials, read)
+
+	partners, err := client.Partners(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(partners))
+	for _, p := range partners {
+		assert.NotEqual(t, serials[0], p.Serial)
+		assert.NotEqual(t, serials[1], p.Serial)
+	}
+}
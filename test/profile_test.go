@@ -0,0 +1,63 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+//Test to profile gas used by solidity functions of mint and withdraw.
+func TestWemixGasProfile(t *testing.T) {
+	contract := depolyWemixSeeded(t)
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", big.NewInt(10))
+	serials := stakeSeeded(t, contract, 3)
+
+	profile := backend.NewGasProfile()
+	contract.GasProfile = profile
+	contract.Tracing = &vm.LogConfig{DisableMemory: true, DisableStack: true, DisableStorage: true}
+
+	mintGas(t, contract)
+	executed := uint64(0)
+	for _, s := range contract.LastTrace.StructLogs {
+		executed += s.GasCost
+	}
+	assert.Equal(t, executed, profile.Total())
+
+	withdrawGas(t, contract, serials[1])
+
+	var buf bytes.Buffer
+	assert.NoError(t, profile.WriteFolded(&buf))
+	folded := map[string]uint64{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		i := strings.LastIndex(scanner.Text(), " ")
+		gas, err := strconv.ParseUint(scanner.Text()[i+1:], 10, 64)
+		assert.NoError(t, err)
+		folded[scanner.Text()[:i]] = gas
+		t.Log(scanner.Text())
+	}
+
+	hasStack := func(prefix string) bool {
+		for k := range folded {
+			if strings.HasPrefix(k, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	assert.True(t, hasStack("WemixToken;mint;_mint"))
+	assert.True(t, hasStack("WemixToken;withdraw"))
+
+	lines := profile.Lines()
+	assert.True(t, len(lines) > 0)
+	assert.True(t, lines[0].Gas >= lines[len(lines)-1].Gas)
+	t.Logf("ok > most expensive line %s:%d(%s) gas %d", lines[0].File, lines[0].Line, lines[0].Function, lines[0].Gas)
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+//lineOf returns the 1-based line of the first line containing substr in the file.
+func lineOf(t *testing.T, file, substr string) int {
+	b, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	for i, line := range strings.Split(string(b), "\n") {
+		if strings.Contains(line, substr) {
+			return i + 1
+		}
+	}
+	t.Fatalf("%q is not in %s", substr, file)
+	return 0
+}
+
+//executeRevert executes a method expected to fail with tracing, and returns the located error.
+func executeRevert(t *testing.T, contract *backend.Contract, key *ecdsa.PrivateKey, method string, arg ...interface{}) *backend.RevertError {
+	contract.Tracing = &vm.LogConfig{DisableMemory: true, DisableStack: true, DisableStorage: true}
+	defer func() { contract.Tracing = nil }()
+
+	r, err := contract.Execute(key, method, arg...)
+	assert.True(t, r.Status == 0)
+	revert, ok := err.(*backend.RevertError)
+	assert.True(t, ok)
+	t.Log(revert)
+	return revert
+}
+
+//Test to locate a require of withdraw in the source.
+func TestWemixRevertWithdraw(t *testing.T) {
+	contract := depolyWemix(t)
+
+	revert := executeRevert(t, contract, nil, "withdraw", big.NewInt(999))
+	assert.Equal(t, contract.File, revert.File)
+	assert.Equal(t, lineOf(t, contract.File, "require(_subIndex < allPartners.length"), revert.Line)
+	assert.True(t, strings.HasPrefix(revert.Snippet, "require(_subIndex < allPartners.length"))
+	assert.Equal(t, []string{"withdraw"}, revert.Stack)
+	assert.Equal(t, "WemixToken: _subIndex equal or higher than allPartners.length", revert.Reason)
+}
+
+//Test to locate a require of an internal function with the call stack.
+func TestWemixRevertTransfer(t *testing.T) {
+	contract := depolyWemix(t)
+
+	key, _ := crypto.GenerateKey()
+	revert := executeRevert(t, contract, key, "transfer", contract.Owner, big.NewInt(1))
+	assert.Equal(t, "ERC20: transfer amount exceeds balance", revert.Reason)
+	assert.True(t, len(revert.Stack) >= 2)
+	assert.Equal(t, "transfer", revert.Stack[0])
+	assert.Contains(t, revert.Stack, "_transfer")
+	assert.Contains(t, revert.Snippet, "require")
+}
+
+//Test to locate a require of an inlined modifier.
+func TestWemixRevertOnlyOwner(t *testing.T) {
+	contract := depolyWemix(t)
+
+	key, _ := crypto.GenerateKey()
+	revert := executeRevert(t, contract, key, "change_wemix", contract.Owner)
+	assert.Equal(t, "Ownable: caller is not the owner", revert.Reason)
+	assert.Equal(t, lineOf(t, contract.File, `require(isOwner(), "Ownable: caller is not the owner")`), revert.Line)
+	assert.Equal(t, "change_wemix", revert.Stack[0])
+	assert.Equal(t, "onlyOwner", revert.Stack[len(revert.Stack)-1])
+}
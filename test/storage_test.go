@@ -0,0 +1,88 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//Test to read private and non-enumerable variables from the contract storage.
+func TestWemixStorage(t *testing.T) {
+	contract := depolyWemix(t)
+
+	checkStorage(t, contract, "WEMIX TOKEN", "_name")
+	checkStorage(t, contract, toBig(t, "1000000000000000000000000000"), "_totalSupply")
+	checkStorage(t, contract, toBig(t, "1000000000000000000000000000"), "_balances", contract.Owner)
+	checkStorage(t, contract, contract.Owner, "_owner")
+	checkStorage(t, contract, big.NewInt(1), "_nextSerial")
+	checkStorage(t, contract, new(big.Int), "allPartners")
+
+	//allowance
+	spender := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	expectedSuccess(t, contract, nil, "approve", spender, big.NewInt(100))
+	checkStorage(t, contract, big.NewInt(100), "_allowances", contract.Owner, spender)
+
+	//stake
+	partnerKey, _ := crypto.GenerateKey()
+	partner := crypto.PubkeyToAddress(partnerKey.PublicKey)
+	expectedSuccess(t, contract, nil, "addAllowedPartner", partner)
+	checkStorage(t, contract, true, "allowedPartners", partner)
+
+	expectedSuccess(t, contract, nil, "stakeDelegated", partner, new(big.Int))
+	checkStorage(t, contract, false, "allowedPartners", partner)
+	checkStorage(t, contract, big.NewInt(2), "_nextSerial")
+	checkStorage(t, contract, big.NewInt(1), "allPartners")
+	checkStorage(t, contract, big.NewInt(1), "allPartners", 0, "serial")
+	checkStorage(t, contract, partner, "allPartners", 0, "partner")
+	checkStorage(t, contract, contract.Owner, "allPartners", 0, "payer")
+	checkStorage(t, contract, new(big.Int), "allPartnersIndex", big.NewInt(1))
+
+	p, err := contract.ReadStorage(nil, "allPartners", 0)
+	assert.NoError(t, err)
+	unitStaking := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&unitStaking, "unitStaking"))
+	assert.Equal(t, toBytes(t, p.(map[string]interface{})["balanceStaking"]), toBytes(t, unitStaking))
+}
+
+//Test to check the pending blockUnitForMint which is applied on the next mint.
+func TestWemixStoragePendingBlockUnitForMint(t *testing.T) {
+	contract := depolyWemix(t)
+
+	checkStorage(t, contract, new(big.Int), "nextBlockUnitForMint")
+
+	expectedSuccess(t, contract, nil, "change_blockUnitForMint", big.NewInt(100))
+	checkStorage(t, contract, big.NewInt(100), "nextBlockUnitForMint")
+	checkVariable(t, contract, "blockUnitForMint", big.NewInt(60))
+
+	blockToMint := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&blockToMint, "blockToMint"))
+	for contract.Backend.Blockchain().CurrentBlock().Header().Number.Cmp(blockToMint) < 0 {
+		contract.Backend.Commit()
+	}
+	expectedSuccess(t, contract, nil, "mint")
+
+	checkStorage(t, contract, new(big.Int), "nextBlockUnitForMint")
+	checkVariable(t, contract, "blockUnitForMint", big.NewInt(100))
+	checkVariable(t, contract, "blockToMint", new(big.Int).Add(blockToMint, big.NewInt(100)))
+}
+
+//Test that the slot of nextBlockUnitForMint known to the client matches the storage layout,
+//and that the variable fills the slot alone.
+func TestWemixStorageSlotForClient(t *testing.T) {
+	contract := depolyWemix(t)
+
+	layout, err := contract.StorageLayout()
+	assert.NoError(t, err)
+	loc, err := layout.Locate("nextBlockUnitForMint")
+	assert.NoError(t, err)
+	assert.Equal(t, wemix.NextBlockUnitForMintSlot, loc.Slot)
+	//the client reads the whole slot as the value
+	assert.Equal(t, 0, loc.Offset)
+	assert.Equal(t, "uint256", loc.Type.Label)
+	assert.Equal(t, "32", loc.Type.NumberOfBytes)
+}
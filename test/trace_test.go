@@ -0,0 +1,107 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//Test to trace a failed transaction and find the revert point.
+func TestWemixTraceRevert(t *testing.T) {
+	contract := depolyWemix(t)
+	contract.Tracing = &vm.LogConfig{DisableMemory: true}
+
+	r, err := contract.Execute(nil, "withdraw", big.NewInt(999))
+	assert.Error(t, err)
+	assert.True(t, r.Status == 0)
+
+	trace := contract.LastTrace
+	assert.NotNil(t, trace)
+	assert.True(t, trace.Failed)
+	assert.Equal(t, r.GasUsed, trace.Gas)
+	assert.NotNil(t, trace.Revert)
+	assert.Equal(t, "REVERT", trace.Revert.Op)
+	assert.Equal(t, 1, trace.Revert.Depth)
+	assert.Equal(t, "WemixToken: _subIndex equal or higher than allPartners.length", trace.RevertReason)
+	assert.Equal(t, contract.Address, trace.Call.To)
+	assert.Equal(t, contract.Owner, trace.Call.From)
+	assert.NotEmpty(t, trace.Call.Error)
+	t.Logf("ok > revert at pc %d, reason: %s", trace.Revert.Pc, trace.RevertReason)
+}
+
+//Test that a trace cut by the limit does not blame a recorded step for the revert.
+func TestWemixTraceLimit(t *testing.T) {
+	contract := depolyWemix(t)
+	contract.Tracing = &vm.LogConfig{DisableMemory: true, Limit: 10}
+
+	_, err := contract.Execute(nil, "withdraw", big.NewInt(999))
+	assert.Error(t, err)
+
+	trace := contract.LastTrace
+	assert.NotNil(t, trace)
+	assert.True(t, trace.Failed)
+	assert.True(t, trace.Truncated)
+	assert.Equal(t, 10, len(trace.StructLogs))
+	assert.Nil(t, trace.Revert)
+	for _, step := range trace.StructLogs {
+		assert.Empty(t, step.Error)
+	}
+	assert.Equal(t, "WemixToken: _subIndex equal or higher than allPartners.length", trace.RevertReason)
+
+	revert := contract.RevertError(trace)
+	assert.NotNil(t, revert)
+	assert.Equal(t, 0, revert.Line)
+}
+
+//Test to trace storage reads and writes, and to write the trace as json.
+func TestWemixTraceStorage(t *testing.T) {
+	contract := depolyWemix(t)
+	contract.Tracing = &vm.LogConfig{}
+
+	partnerKey, _ := crypto.GenerateKey()
+	partner := crypto.PubkeyToAddress(partnerKey.PublicKey)
+	expectedSuccess(t, contract, nil, "addAllowedPartner", partner)
+
+	trace := contract.LastTrace
+	assert.False(t, trace.Failed)
+	assert.Nil(t, trace.Revert)
+
+	layout, err := contract.StorageLayout()
+	assert.NoError(t, err)
+	loc, err := layout.Locate("allowedPartners", partner)
+	assert.NoError(t, err)
+
+	written := false
+	for _, a := range trace.StorageAccesses {
+		if a.Write && a.Slot == loc.Slot {
+			assert.Equal(t, common.BigToHash(big.NewInt(1)), a.Value)
+			assert.Equal(t, "SSTORE", trace.StructLogs[a.Step].Op)
+			written = true
+		}
+	}
+	assert.True(t, written)
+
+	var buf bytes.Buffer
+	assert.NoError(t, trace.WriteJSON(&buf))
+
+	result := struct {
+		Gas         uint64                   `json:"gas"`
+		Failed      bool                     `json:"failed"`
+		ReturnValue string                   `json:"returnValue"`
+		StructLogs  []map[string]interface{} `json:"structLogs"`
+	}{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, trace.Gas, result.Gas)
+	assert.Equal(t, len(trace.StructLogs), len(result.StructLogs))
+	assert.Contains(t, result.StructLogs[0], "pc")
+	assert.Contains(t, result.StructLogs[0], "op")
+	assert.Contains(t, result.StructLogs[0], "gasCost")
+	t.Logf("ok > trace json size: %d, steps: %d", buf.Len(), len(result.StructLogs))
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+func TestUnlockReportFormats(t *testing.T) {
+	at := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	unit := toBig(t, "2000000000000000000000000")
+	later := at.Add(time.Hour)
+	report := &wemix.UnlockReport{
+		Contract:  common.HexToAddress("0x5096db80b21ef45230c9e423c373f1fc9c0198dd"),
+		Block:     big.NewInt(1000),
+		Time:      at,
+		BlockTime: time.Second,
+		Stakes: []wemix.UnlockEntry{
+			{Serial: big.NewInt(1), Partner: common.Address{1}, Payer: common.Address{2}, Amount: unit,
+				UnlockBlock: big.NewInt(900), Remaining: new(big.Int), UnlockTime: &at, Withdrawable: true},
+			{Serial: big.NewInt(2), Partner: common.Address{3}, Payer: common.Address{2}, Amount: unit,
+				UnlockBlock: big.NewInt(4600), Remaining: big.NewInt(3600), UnlockTime: &later},
+			{Serial: big.NewInt(3), Partner: common.Address{4}, Payer: common.Address{2}, Amount: unit,
+				UnlockBlock: toBig(t, "10000000000000000000000"), Remaining: toBig(t, "9999999999999999999000")},
+		},
+	}
+
+	assert.True(t, report.EstimateTime(big.NewInt(3600)).Equal(later))
+	assert.Nil(t, report.EstimateTime(report.Stakes[2].Remaining))
+	assert.Nil(t, report.EstimateTime(big.NewInt(math.MaxInt64/int64(time.Second)+1)))
+	assert.NotNil(t, report.EstimateTime(big.NewInt(math.MaxInt64/int64(time.Second))))
+
+	var b bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&b))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, "serial,partner,payer,amount,unlock_block,remaining,unlock_time,withdrawable", lines[0])
+	assert.Equal(t, "2,0x0300000000000000000000000000000000000000,0x0200000000000000000000000000000000000000,2000000000000000000000000,4600,3600,2021-03-01T01:00:00Z,false", lines[2])
+	assert.True(t, strings.HasSuffix(lines[3], ",9999999999999999999000,,false"), lines[3]) //no unlock time
+
+	b.Reset()
+	assert.NoError(t, report.WriteTable(&b))
+	assert.Contains(t, b.String(), "2000000 WEMIX")
+
+	b.Reset()
+	assert.NoError(t, report.WriteICalendar(&b))
+	ics := b.String()
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.True(t, len(line) <= 75, line)
+	}
+	assert.Equal(t, 1, strings.Count(ics, "BEGIN:VEVENT")) //serial 1 is withdrawable already, and serial 3 has no unlock time
+	assert.Equal(t, 1, strings.Count(ics, "BEGIN:VALARM"))
+	unfolded := strings.Replace(ics, "\r\n ", "", -1)
+	assert.Contains(t, unfolded, "\r\nDTSTART:20210301T010000Z\r\n")
+	assert.Contains(t, unfolded, "\r\nSUMMARY:WEMIX stake 2 unlocks\r\n")
+	assert.Contains(t, unfolded, "UID:unlock-0x5096db80b21ef45230c9e423c373f1fc9c0198dd-2@wemix-token")
+	assert.Contains(t, unfolded, `paid by 0x0200000000000000000000000000000000000000\, can be withdrawn`)
+
+	out, err := json.Marshal(report)
+	assert.NoError(t, err)
+	decoded := wemix.UnlockReport{}
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, 0, decoded.Stakes[1].UnlockBlock.Cmp(big.NewInt(4600)))
+	assert.True(t, decoded.Stakes[1].UnlockTime.Equal(later))
+	assert.Nil(t, decoded.Stakes[2].UnlockTime)
+}
+
+//Test unlock blocks and times of stakes read from the contract, with the block time of the simulated backend.
+func TestUnlockReport(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", new(big.Int))
+	owner, err := wemix.NewClient(contract.Address, contract.Backend, contract.OwnerKey)
+	assert.NoError(t, err)
+	for i, wait := range []int64{0, 100} {
+		partner := crypto.PubkeyToAddress(seedKey(t, "unlock partner"+string(rune('a'+i))).PublicKey)
+		_, err := owner.AllowPartner(ctx, partner)
+		assert.NoError(t, err)
+		_, err = owner.StakeFor(ctx, partner, big.NewInt(wait))
+		assert.NoError(t, err)
+	}
+	contract.Backend.Commit()
+
+	blockTime, err := owner.EstimateBlockTime(ctx, nil, wemix.DefaultBlockTimeWindow)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, blockTime) //each block of the simulated backend is 10 seconds later
+
+	report, err := owner.UnlockReport(ctx, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, blockTime, report.BlockTime)
+	assert.Equal(t, 2, len(report.Stakes))
+	head := contract.Backend.Blockchain().CurrentBlock()
+	assert.Equal(t, head.Number(), report.Block)
+
+	assert.True(t, report.Stakes[0].Withdrawable)
+	assert.Equal(t, 0, report.Stakes[0].Remaining.Sign())
+	assert.True(t, report.Stakes[0].UnlockTime.Equal(report.Time))
+
+	e := report.Stakes[1]
+	assert.False(t, e.Withdrawable)
+	assert.Equal(t, contract.Owner, e.Payer)
+	assert.Equal(t, new(big.Int).Sub(e.UnlockBlock, head.Number()), e.Remaining)
+	assert.True(t, e.UnlockTime.Equal(time.Unix(int64(head.Time()), 0).Add(time.Duration(e.Remaining.Int64())*blockTime)))
+
+	//a given block time
+	report, err = owner.UnlockReport(ctx, nil, time.Second)
+	assert.NoError(t, err)
+	assert.True(t, report.Stakes[1].UnlockTime.Equal(report.Time.Add(time.Duration(e.Remaining.Int64())*time.Second)))
+}
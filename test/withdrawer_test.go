@@ -0,0 +1,181 @@
+package test
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/wemix"
+)
+
+//lostBackend loses every transaction sent, like a node crashing before the agent sends.
+type lostBackend struct {
+	*backends.SimulatedBackend
+}
+
+func (lostBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	journal, err := wemix.OpenJournal(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(journal.Entries()))
+
+	assert.NoError(t, journal.Append(wemix.JournalEntry{Serial: big.NewInt(2), Status: wemix.JournalSent, Nonce: 1}))
+	assert.NoError(t, journal.Append(wemix.JournalEntry{Serial: big.NewInt(1), Status: wemix.JournalSent}))
+	assert.NoError(t, journal.Append(wemix.JournalEntry{Serial: big.NewInt(2), Status: wemix.JournalWithdrawn, Block: big.NewInt(9)}))
+
+	//a line cut by a crash
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.NoError(t, err)
+	f.WriteString(`{"serial":1,"status":"withd`)
+	f.Close()
+
+	reopened, err := wemix.OpenJournal(path)
+	assert.NoError(t, err)
+	entries := reopened.Entries()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, big.NewInt(1), entries[0].Serial)
+	assert.Equal(t, wemix.JournalSent, entries[0].Status)
+	e, ok := reopened.Entry(big.NewInt(2))
+	assert.True(t, ok)
+	assert.Equal(t, wemix.JournalWithdrawn, e.Status)
+	assert.Equal(t, big.NewInt(9), e.Block)
+
+	//appending after the cut line keeps the journal readable
+	assert.NoError(t, reopened.Append(wemix.JournalEntry{Serial: big.NewInt(1), Status: wemix.JournalWithdrawn, Block: big.NewInt(11)}))
+	reopened, err = wemix.OpenJournal(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(reopened.Entries()))
+	e, ok = reopened.Entry(big.NewInt(1))
+	assert.True(t, ok)
+	assert.Equal(t, wemix.JournalWithdrawn, e.Status)
+	assert.Equal(t, big.NewInt(11), e.Block)
+
+	//a broken line in the middle is an error
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(path, append([]byte("{\n"), b...), 0600))
+	_, err = wemix.OpenJournal(path)
+	assert.Error(t, err)
+}
+
+//Test the agent withdrawing stakes paid by the owner once they are unlocked, through restarts:
+//a withdrawal journaled but lost is sent again, and a withdrawal pending is not sent twice.
+func TestWithdrawer(t *testing.T) {
+	contract := depolyWemix(t)
+	ctx := context.Background()
+	expectedSuccess(t, contract, nil, "change_minBlockWaitingWithdrawal", new(big.Int))
+	owner, err := wemix.NewClient(contract.Address, contract.Backend, contract.OwnerKey)
+	assert.NoError(t, err)
+
+	//stakes paid by the owner with 20, 30 and 40 blocks of waiting, and one paid by another account
+	serials := []*big.Int{}
+	for i, wait := range []int64{20, 30, 40} {
+		partner := crypto.PubkeyToAddress(seedKey(t, "withdrawer partner"+string(rune('a'+i))).PublicKey)
+		_, err := owner.AllowPartner(ctx, partner)
+		assert.NoError(t, err)
+		serial, err := owner.StakeFor(ctx, partner, big.NewInt(wait))
+		assert.NoError(t, err)
+		serials = append(serials, serial)
+	}
+	otherKey := seedKey(t, "withdrawer other payer")
+	unit := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&unit, "unitStaking"))
+	expectedSuccess(t, contract, nil, "transfer", crypto.PubkeyToAddress(otherKey.PublicKey), unit)
+	other, err := wemix.NewClient(contract.Address, contract.Backend, otherKey)
+	assert.NoError(t, err)
+	otherPartner := crypto.PubkeyToAddress(seedKey(t, "withdrawer partner of other").PublicKey)
+	_, err = owner.AllowPartner(ctx, otherPartner)
+	assert.NoError(t, err)
+	otherSerial, err := other.StakeFor(ctx, otherPartner, new(big.Int))
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "journal")
+	start := func(backend wemix.Backend) *wemix.Withdrawer {
+		client, err := wemix.NewClient(contract.Address, backend, contract.OwnerKey)
+		assert.NoError(t, err)
+		journal, err := wemix.OpenJournal(path)
+		assert.NoError(t, err)
+		w, err := wemix.NewWithdrawer(client, journal)
+		assert.NoError(t, err)
+		w.Policy = wemix.HoldSerials(serials[2])
+		return w
+	}
+	nonce := func() uint64 {
+		n, err := contract.Backend.PendingNonceAt(ctx, contract.Owner)
+		assert.NoError(t, err)
+		return n
+	}
+
+	w := start(contract.Backend)
+	stakes, err := w.Stakes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(stakes))
+	assert.Equal(t, []bool{true, true, false}, []bool{stakes[0].Released, stakes[1].Released, stakes[2].Released})
+	unlock := stakes[0].Unlock
+
+	//not eligible in the next block
+	commitUntil(contract, new(big.Int).Sub(unlock, big.NewInt(2)))
+	before := nonce()
+	assert.NoError(t, w.Poll(ctx))
+	assert.Equal(t, before, nonce())
+
+	//journaled, and lost before it reached the node
+	contract.Backend.Commit()
+	lost := start(lostBackend{contract.Backend})
+	assert.NoError(t, lost.Poll(ctx))
+	e, ok := lost.Journal.Entry(serials[0])
+	assert.True(t, ok)
+	assert.Equal(t, wemix.JournalSent, e.Status)
+	assert.Equal(t, before, nonce())
+
+	//a restart sends the same transaction again, and the next restart waits for it
+	w = start(contract.Backend)
+	assert.NoError(t, w.Poll(ctx))
+	assert.Equal(t, before+1, nonce())
+	w = start(contract.Backend)
+	assert.NoError(t, w.Poll(ctx))
+	assert.Equal(t, before+1, nonce())
+
+	balance := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&balance, "balanceOf", contract.Owner))
+	contract.Backend.Commit()
+	assert.NoError(t, w.Poll(ctx))
+	e, _ = w.Journal.Entry(serials[0])
+	assert.Equal(t, wemix.JournalWithdrawn, e.Status)
+	withdrawn := (*big.Int)(nil)
+	assert.NoError(t, contract.Call(&withdrawn, "balanceOf", contract.Owner))
+	assert.Equal(t, new(big.Int).Add(balance, unit), withdrawn)
+
+	//the second stake is withdrawn, the third is held by the policy, and the one of the other payer is not touched
+	commitUntil(contract, new(big.Int).Add(stakes[2].Unlock, big.NewInt(1)))
+	assert.NoError(t, w.Poll(ctx))
+	contract.Backend.Commit()
+	assert.NoError(t, w.Poll(ctx))
+	e, _ = w.Journal.Entry(serials[1])
+	assert.Equal(t, wemix.JournalWithdrawn, e.Status)
+	_, ok = w.Journal.Entry(serials[2])
+	assert.False(t, ok)
+	_, ok = w.Journal.Entry(otherSerial)
+	assert.False(t, ok)
+
+	stakes, err = w.Stakes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(stakes))
+	assert.Equal(t, serials[2], stakes[0].Serial)
+	assert.True(t, stakes[0].Eligible)
+	partners, err := owner.Partners(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(partners))
+}
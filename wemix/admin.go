@@ -0,0 +1,176 @@
+package wemix
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//AdminAction is a successful transaction calling an owner only method of the contract.
+//The contract emits no event for most of them, so they are found from calldata.
+type AdminAction struct {
+	Block   *big.Int
+	Time    time.Time
+	TxHash  common.Hash
+	TxIndex uint
+	Sender  common.Address
+	Method  string
+	Args    []interface{}
+
+	Param string      //parameter changed, the getter name or nextBlockUnitForMint
+	Key   interface{} //key of the mapping for allowedPartners, nil otherwise
+	Old   interface{} //value before the transaction, nil if it is unknown
+	New   interface{} //value after the transaction
+}
+
+func (a *AdminAction) String() string {
+	param := a.Param
+	if a.Key != nil {
+		param = fmt.Sprintf("%s[%v]", a.Param, a.Key)
+	}
+	return fmt.Sprintf("block %v %s %s by %s: %s %v -> %v",
+		a.Block, a.Time.UTC().Format(time.RFC3339), a.Method, a.Sender.Hex(), param, a.Old, a.New)
+}
+
+//adminMethod is how an owner only method changes a parameter.
+type adminMethod struct {
+	param string
+	keyed bool                                 //the first argument is the key of the mapping
+	value func(args []interface{}) interface{} //new value from the arguments
+}
+
+func firstArg(args []interface{}) interface{} {
+	return args[0]
+}
+
+var adminMethods = map[string]adminMethod{
+	"change_ecoFund":                   {param: "ecoFund", value: firstArg},
+	"change_wemix":                     {param: "wemix", value: firstArg},
+	"change_minBlockWaitingWithdrawal": {param: "minBlockWaitingWithdrawal", value: firstArg},
+	"change_unitStaking":               {param: "unitStaking", value: firstArg},
+	"change_mintToPartner":             {param: "mintToPartner", value: firstArg},
+	"change_mintToWemix":               {param: "mintToWemix", value: firstArg},
+	"change_mintToEcoFund":             {param: "mintToEcoFund", value: firstArg},
+	"change_blockUnitForMint":          {param: "nextBlockUnitForMint", value: firstArg},
+	"addAllowedPartner":                {param: "allowedPartners", keyed: true, value: func([]interface{}) interface{} { return true }},
+	"removeAllowedPartner":             {param: "allowedPartners", keyed: true, value: func([]interface{}) interface{} { return false }},
+	"transferOwnership":                {param: "owner", value: firstArg},
+	"renounceOwnership":                {param: "owner", value: func([]interface{}) interface{} { return common.Address{} }},
+}
+
+//AdminHistory scans blocks from from to to inclusive, and returns successful admin actions in the order executed.
+//Old values are read at the parent block, or taken from an earlier action of the same block.
+//Any other transaction before it in the block may change the value too, as stake() clears allowedPartners
+//and mint() applies nextBlockUnitForMint, so the old value is unknown then,
+//since the state in the middle of a block can not be read.
+//Only top level transactions sent directly to the contract are found,
+//not calls made through a multisig wallet or any other contract.
+func (p *Client) AdminHistory(ctx context.Context, from, to *big.Int) ([]*AdminAction, error) {
+	ret := []*AdminAction{}
+	for number := new(big.Int).Set(from); number.Cmp(to) <= 0; number.Add(number, common.Big1) {
+		block, err := p.backend.BlockByNumber(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("block %v: %w", number, err)
+		}
+		//values changed by earlier actions in the block, and whether another transaction came before
+		changed, others := map[string]interface{}{}, false
+		for i, tx := range block.Transactions() {
+			a, err := p.adminAction(ctx, block, tx)
+			if err != nil {
+				return nil, fmt.Errorf("tx %s: %w", tx.Hash().Hex(), err)
+			}
+			if a == nil {
+				others = true
+				changed = map[string]interface{}{}
+				continue
+			}
+			a.TxIndex = uint(i)
+			key := fmt.Sprint(a.Param, a.Key)
+			if old, ok := changed[key]; ok {
+				a.Old = old
+			} else if others == false {
+				if a.Old, err = p.paramAt(ctx, a.Param, a.Key, new(big.Int).Sub(block.Number(), common.Big1)); err != nil {
+					return nil, fmt.Errorf("tx %s: %w", tx.Hash().Hex(), err)
+				}
+			}
+			changed[key] = a.New
+			ret = append(ret, a)
+		}
+	}
+	return ret, nil
+}
+
+//adminAction returns the admin action of the transaction, or nil if it is not one.
+func (p *Client) adminAction(ctx context.Context, block *types.Block, tx *types.Transaction) (*AdminAction, error) {
+	if tx.To() == nil || *tx.To() != p.Address || len(tx.Data()) < 4 {
+		return nil, nil
+	}
+	method, err := tokenABI.MethodById(tx.Data()[:4])
+	if err != nil {
+		return nil, nil
+	}
+	m, ok := adminMethods[method.Name]
+	if ok == false {
+		return nil, nil
+	}
+	receipt, err := p.backend.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, nil
+	}
+	args, err := method.Inputs.UnpackValues(tx.Data()[4:])
+	if err != nil {
+		return nil, err
+	}
+	//an unprotected transaction falls back to the homestead signer.
+	sender, err := types.Sender(types.NewEIP155Signer(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &AdminAction{
+		Block:  block.Number(),
+		Time:   time.Unix(int64(block.Time()), 0),
+		TxHash: tx.Hash(),
+		Sender: sender,
+		Method: method.Name,
+		Args:   args,
+		Param:  m.param,
+		New:    m.value(args),
+	}
+	if m.keyed {
+		a.Key = args[0]
+	}
+	return a, nil
+}
+
+//paramAt reads a parameter changed by admin methods at the block.
+func (p *Client) paramAt(ctx context.Context, param string, key interface{}, block *big.Int) (interface{}, error) {
+	if param == "nextBlockUnitForMint" {
+		value, err := p.backend.StorageAt(ctx, p.Address, NextBlockUnitForMintSlot, block)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(value), nil
+	}
+	batch := p.NewBatch()
+	if key != nil {
+		batch.Add(param, key)
+	} else {
+		batch.Add(param)
+	}
+	results, err := batch.Run(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if results[0].Err != nil {
+		return nil, results[0].Err
+	}
+	return results[0].Values[0], nil
+}
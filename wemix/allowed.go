@@ -0,0 +1,129 @@
+package wemix
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//AllowedPartner is an address pre-approved by the owner which has not staked yet.
+type AllowedPartner struct {
+	Address common.Address
+	Block   *big.Int    //block of the last addAllowedPartner
+	TxHash  common.Hash //tx of the last addAllowedPartner
+}
+
+//AllowedMismatch is an address whose rebuilt state differs from the allowedPartners getter.
+type AllowedMismatch struct {
+	Address  common.Address
+	Expected bool //rebuilt from the history
+	Actual   bool //allowedPartners(address)
+}
+
+//AllowedPartnersReport is the set of allowed partners rebuilt from history at a block.
+type AllowedPartnersReport struct {
+	Block      *big.Int
+	Allowed    []*AllowedPartner //ordered by the block approved
+	Mismatches []AllowedMismatch
+}
+
+//allowedEvent is a change of allowedPartners in the history.
+type allowedEvent struct {
+	block   *big.Int
+	txIndex uint
+	txHash  common.Hash
+	address common.Address
+	allowed bool
+}
+
+//RebuildAllowedPartners replays addAllowedPartner and removeAllowedPartner calls and Staked events,
+//which clear the approval, from blocks from to to inclusive, and rebuilds the set of allowed partners at to.
+//from must be at or before the deployment for a complete set. Every address seen is cross-checked
+//with the allowedPartners getter at to, and differences are reported as mismatches.
+func (p *Client) RebuildAllowedPartners(ctx context.Context, from, to *big.Int) (*AllowedPartnersReport, error) {
+	events := []allowedEvent{}
+
+	history, err := p.AdminHistory(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range history {
+		if a.Param != "allowedPartners" {
+			continue
+		}
+		events = append(events, allowedEvent{
+			block:   a.Block,
+			txIndex: a.TxIndex,
+			txHash:  a.TxHash,
+			address: a.Key.(common.Address),
+			allowed: a.New.(bool),
+		})
+	}
+
+	end := to.Uint64()
+	it, err := p.Token.FilterStaked(&bind.FilterOpts{Start: from.Uint64(), End: &end, Context: ctx}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for it.Next() {
+		events = append(events, allowedEvent{
+			block:   new(big.Int).SetUint64(it.Event.Raw.BlockNumber),
+			txIndex: it.Event.Raw.TxIndex,
+			txHash:  it.Event.Raw.TxHash,
+			address: it.Event.Partner,
+			allowed: false,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	//a transaction is either an admin call or a stake, so the block and the index order them.
+	sort.SliceStable(events, func(i, j int) bool {
+		if c := events[i].block.Cmp(events[j].block); c != 0 {
+			return c < 0
+		}
+		return events[i].txIndex < events[j].txIndex
+	})
+
+	state := map[common.Address]*AllowedPartner{}
+	seen := []common.Address{}
+	for _, e := range events {
+		if _, ok := state[e.address]; ok == false {
+			seen = append(seen, e.address)
+		}
+		state[e.address] = nil
+		if e.allowed {
+			state[e.address] = &AllowedPartner{Address: e.address, Block: e.block, TxHash: e.txHash}
+		}
+	}
+
+	r := &AllowedPartnersReport{Block: to, Allowed: []*AllowedPartner{}, Mismatches: []AllowedMismatch{}}
+	batch := p.NewBatch()
+	for _, address := range seen {
+		batch.Add("allowedPartners", address)
+		if a := state[address]; a != nil {
+			r.Allowed = append(r.Allowed, a)
+		}
+	}
+	sort.SliceStable(r.Allowed, func(i, j int) bool { return r.Allowed[i].Block.Cmp(r.Allowed[j].Block) < 0 })
+
+	results, err := batch.Run(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	for i, address := range seen {
+		if results[i].Err != nil {
+			return nil, results[i].Err
+		}
+		expected, actual := state[address] != nil, results[i].Values[0].(bool)
+		if expected != actual {
+			r.Mismatches = append(r.Mismatches, AllowedMismatch{Address: address, Expected: expected, Actual: actual})
+		}
+	}
+	return r, nil
+}
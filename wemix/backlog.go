@@ -0,0 +1,113 @@
+package wemix
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/model"
+)
+
+//MintState returns the state used by mint() at the block, or the current block if it is nil, as a model.
+//Reading a block which is not the latest needs a node keeping its state, like an archive node.
+func (p *Client) MintState(ctx context.Context, block *big.Int) (*model.Mint, error) {
+	params, err := p.ParamsAt(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if params.NextPartnerToMint.IsUint64() == false {
+		return nil, fmt.Errorf("wemix: nextPartnerToMint %v", params.NextPartnerToMint)
+	}
+	r := &model.Mint{
+		NextPartnerToMint:    params.NextPartnerToMint.Uint64(),
+		BlockUnitForMint:     params.BlockUnitForMint,
+		NextBlockUnitForMint: params.NextBlockUnitForMint,
+		BlockToMint:          params.BlockToMint,
+		MintToPartner:        params.MintToPartner,
+		MintToWemix:          params.MintToWemix,
+		MintToEcoFund:        params.MintToEcoFund,
+		Wemix:                params.Wemix,
+		EcoFund:              params.EcoFund,
+	}
+	it, err := p.PartnerIterator(ctx, params.Block, 0)
+	if err != nil {
+		return nil, err
+	}
+	for it.Next() {
+		for _, partner := range it.Page() {
+			r.Stake(model.Partner(*partner))
+		}
+	}
+	return r, it.Err()
+}
+
+//MintBacklog returns mint() calls outstanding at the block, or the current block if it is nil,
+//and tokens they mint to each recipient.
+//A call sent now is in a later block, where a few more calls may be outstanding.
+func (p *Client) MintBacklog(ctx context.Context, block *big.Int) (*model.Backlog, error) {
+	m, err := p.MintState(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		head, err := p.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		block = head.Number
+	}
+	return m.Backlog(block)
+}
+
+//CatchUp is mint() calls clearing a backlog on the simulated backend.
+type CatchUp struct {
+	Backlog  *model.Backlog              `json:"backlog"` //predicted before the calls
+	Gas      []uint64                    `json:"gas"`     //gas used by each call
+	GasTotal uint64                      `json:"gasTotal"`
+	Minted   map[common.Address]*big.Int `json:"minted"` //by Transfer events of the calls
+}
+
+//SimulateCatchUp sends the mint() calls outstanding in the next block of the contract one after another, each in its own block,
+//and returns gas used and tokens minted by them. The owner sends the calls.
+func SimulateCatchUp(contract *backend.Contract) (*CatchUp, error) {
+	client, err := NewClient(contract.Address, contract.Backend, nil)
+	if err != nil {
+		return nil, err
+	}
+	head := contract.Backend.Blockchain().CurrentBlock().Number()
+	m, err := client.MintState(context.Background(), head)
+	if err != nil {
+		return nil, err
+	}
+	backlog, err := m.Backlog(new(big.Int).Add(head, common.Big1))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CatchUp{Backlog: backlog, Minted: map[common.Address]*big.Int{}}
+	transfer := contract.Abi.Events["Transfer"].ID
+	for i := uint64(0); i < backlog.Mints; i++ {
+		receipt, err := contract.Execute(nil, "mint")
+		if err != nil {
+			return r, fmt.Errorf("mint %d: %w", i+1, err)
+		}
+		if receipt.Status != 1 {
+			return r, fmt.Errorf("mint %d: %w", i+1, ErrReverted)
+		}
+		r.Gas = append(r.Gas, receipt.GasUsed)
+		r.GasTotal += receipt.GasUsed
+		for _, g := range receipt.Logs {
+			if g.Topics[0] != transfer || g.Topics[1] != (common.Hash{}) {
+				continue
+			}
+			to := common.BytesToAddress(g.Topics[2].Bytes())
+			if _, ok := r.Minted[to]; ok == false {
+				r.Minted[to] = new(big.Int)
+			}
+			r.Minted[to].Add(r.Minted[to], new(big.Int).SetBytes(g.Data))
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,229 @@
+package wemix
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/wemade-tree/wemix-token/binding"
+)
+
+//BatchCaller executes many calls at a block. It returns the output or the error of each call in order,
+//and an error when the batch itself failed.
+type BatchCaller interface {
+	BatchCall(ctx context.Context, calls []ethereum.CallMsg, block *big.Int) ([][]byte, []error, error)
+}
+
+//RPCBatchCaller sends calls as a single JSON-RPC batch of eth_call.
+type RPCBatchCaller struct {
+	Client *rpc.Client
+}
+
+//BatchCall implements BatchCaller.
+func (p *RPCBatchCaller) BatchCall(ctx context.Context, calls []ethereum.CallMsg, block *big.Int) ([][]byte, []error, error) {
+	blockArg := "latest"
+	if block != nil {
+		blockArg = hexutil.EncodeBig(block)
+	}
+	outs := make([]hexutil.Bytes, len(calls))
+	elems := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		arg := map[string]interface{}{
+			"from": call.From,
+			"to":   call.To,
+			"data": hexutil.Bytes(call.Data),
+		}
+		if call.Gas != 0 {
+			arg["gas"] = hexutil.Uint64(call.Gas)
+		}
+		elems[i] = rpc.BatchElem{Method: "eth_call", Args: []interface{}{arg, blockArg}, Result: &outs[i]}
+	}
+	if err := p.Client.BatchCallContext(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+	ret, errs := make([][]byte, len(calls)), make([]error, len(calls))
+	for i := range elems {
+		ret[i], errs[i] = outs[i], elems[i].Error
+	}
+	return ret, errs, nil
+}
+
+//sequentialCaller calls one by one, for backends without batches like the simulated backend.
+type sequentialCaller struct {
+	caller bind.ContractCaller
+}
+
+func (p *sequentialCaller) BatchCall(ctx context.Context, calls []ethereum.CallMsg, block *big.Int) ([][]byte, []error, error) {
+	ret, errs := make([][]byte, len(calls)), make([]error, len(calls))
+	for i, call := range calls {
+		ret[i], errs[i] = p.caller.CallContract(ctx, call, block)
+	}
+	return ret, errs, nil
+}
+
+//Dial connects to a node by RPC, and returns a client whose batches are JSON-RPC batch requests.
+func Dial(rawurl string, address common.Address, key *ecdsa.PrivateKey) (*Client, error) {
+	rc, err := rpc.Dial(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewClient(address, ethclient.NewClient(rc), key)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	r.BatchCaller = &RPCBatchCaller{Client: rc}
+	return r, nil
+}
+
+var tokenABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(binding.WemixTokenABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+//Batch is view calls of the contract executed together at a block.
+type Batch struct {
+	client  *Client
+	methods []string
+	calls   []ethereum.CallMsg
+	err     error
+}
+
+//BatchResult is the decoded output of a call in a batch.
+type BatchResult struct {
+	Method string
+	Values []interface{}
+	Err    error
+}
+
+//NewBatch returns an empty batch.
+func (p *Client) NewBatch() *Batch {
+	return &Batch{client: p}
+}
+
+//Add adds a view call of the method, and returns its index in the results.
+func (b *Batch) Add(method string, args ...interface{}) int {
+	data, err := tokenABI.Pack(method, args...)
+	if err != nil && b.err == nil {
+		b.err = fmt.Errorf("%s: %w", method, err)
+	}
+	b.methods = append(b.methods, method)
+	b.calls = append(b.calls, ethereum.CallMsg{From: b.client.from, To: &b.client.Address, Data: data})
+	return len(b.calls) - 1
+}
+
+//Len returns the number of calls added.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+//Run executes the calls at the block, or the latest block if it is nil,
+//and returns their decoded outputs in the order added.
+func (b *Batch) Run(ctx context.Context, block *big.Int) ([]BatchResult, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.calls) == 0 {
+		return []BatchResult{}, nil
+	}
+	outs, errs, err := b.client.batchCaller().BatchCall(ctx, b.calls, block)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]BatchResult, len(b.calls))
+	for i, method := range b.methods {
+		ret[i] = BatchResult{Method: method, Err: errs[i]}
+		if errs[i] != nil {
+			continue
+		}
+		if len(outs[i]) == 0 {
+			ret[i].Err = bind.ErrNoCode
+			continue
+		}
+		ret[i].Values, ret[i].Err = tokenABI.Methods[method].Outputs.UnpackValues(outs[i])
+	}
+	return ret, nil
+}
+
+func (p *Client) batchCaller() BatchCaller {
+	if p.BatchCaller != nil {
+		return p.BatchCaller
+	}
+	return &sequentialCaller{caller: p.backend}
+}
+
+//PartnerBalance is a partner with the token balance of its partner address.
+type PartnerBalance struct {
+	*Partner
+	Balance *big.Int
+}
+
+//PartnersWithBalances returns all partners and their balances at the block, or the current block if it is nil.
+//It takes three batches instead of a call per partner and balance.
+func (p *Client) PartnersWithBalances(ctx context.Context, block *big.Int) ([]PartnerBalance, error) {
+	if block == nil {
+		head, err := p.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		block = head.Number
+	}
+
+	batch := p.NewBatch()
+	batch.Add("partnersNumber")
+	results, err := batch.Run(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if results[0].Err != nil {
+		return nil, results[0].Err
+	}
+	n := results[0].Values[0].(*big.Int).Int64()
+
+	batch = p.NewBatch()
+	for i := int64(0); i < n; i++ {
+		batch.Add("partnerByIndex", big.NewInt(i))
+	}
+	if results, err = batch.Run(ctx, block); err != nil {
+		return nil, err
+	}
+	ret := make([]PartnerBalance, n)
+	balances := p.NewBatch()
+	for i, r := range results {
+		if r.Err != nil {
+			return nil, fmt.Errorf("partner %d at block %v: %w", i, block, r.Err)
+		}
+		ret[i].Partner = &Partner{
+			Serial:                 r.Values[0].(*big.Int),
+			Partner:                r.Values[1].(common.Address),
+			Payer:                  r.Values[2].(common.Address),
+			BlockStaking:           r.Values[3].(*big.Int),
+			BlockWaitingWithdrawal: r.Values[4].(*big.Int),
+			BalanceStaking:         r.Values[5].(*big.Int),
+		}
+		balances.Add("balanceOf", ret[i].Partner.Partner)
+	}
+
+	if results, err = balances.Run(ctx, block); err != nil {
+		return nil, err
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			return nil, fmt.Errorf("balance of partner %d at block %v: %w", i, block, r.Err)
+		}
+		ret[i].Balance = r.Values[0].(*big.Int)
+	}
+	return ret, nil
+}
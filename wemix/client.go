@@ -0,0 +1,277 @@
+//Package wemix is a client of the WemixToken contract for staking, withdrawal, minting and partner management.
+//Every transaction is checked against the contract state before it is sent,
+//so that a call which would revert returns a domain error instead.
+package wemix
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wemade-tree/wemix-token/binding"
+)
+
+var (
+	ErrReadOnly            = errors.New("wemix: client has no key to send transactions")
+	ErrZeroAddress         = errors.New("wemix: zero address")
+	ErrNotAllowedPartner   = errors.New("wemix: partner is not pre-approved")
+	ErrInsufficientBalance = errors.New("wemix: balance is lower than unitStaking")
+	ErrPartnerNotFound     = errors.New("wemix: no partner with the serial")
+	ErrNotPayer            = errors.New("wemix: only the payer can withdraw")
+	ErrNotWithdrawable     = errors.New("wemix: withdrawal waiting blocks have not passed")
+	ErrNotMintable         = errors.New("wemix: blockToMint is higher than the block")
+	ErrNotOwner            = errors.New("wemix: caller is not the owner")
+	ErrReverted            = errors.New("wemix: transaction reverted")
+)
+
+//Backend is what the client needs from a node, satisfied by ethclient.Client and backends.SimulatedBackend.
+//A backend with Commit(), like the simulated backend, is committed after each transaction.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+//Client sends transactions to the contract from an account.
+type Client struct {
+	Address common.Address //contract address
+	Token   *binding.WemixToken
+
+	//BatchCaller executes batches made by NewBatch. Calls are sent one by one if it is nil.
+	BatchCaller BatchCaller
+
+	//GasPrice of transactions, suggested by the node if it is nil.
+	//NewClient sets 0 on a simulated backend, whose accounts have no ether.
+	GasPrice *big.Int
+	//GasLimit of transactions, estimated if it is 0.
+	GasLimit uint64
+
+	backend Backend
+	key     *ecdsa.PrivateKey
+	from    common.Address
+}
+
+//NewClient returns a client of the contract at the address.
+//key signs transactions, and it can be nil for a read only client.
+func NewClient(address common.Address, backend Backend, key *ecdsa.PrivateKey) (*Client, error) {
+	token, err := binding.NewWemixToken(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	r := &Client{
+		Address: address,
+		Token:   token,
+		backend: backend,
+		key:     key,
+	}
+	if key != nil {
+		r.from = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	if _, ok := backend.(interface{ Commit() }); ok {
+		r.GasPrice = new(big.Int)
+	}
+	return r, nil
+}
+
+//From returns the account sending transactions.
+func (p *Client) From() common.Address {
+	return p.from
+}
+
+func (p *Client) callOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx, From: p.from}
+}
+
+//Stake stakes unitStaking of the account with the account as the partner, and returns the serial of the stake.
+//waitBlocks lower than minBlockWaitingWithdrawal is raised to it by the contract.
+func (p *Client) Stake(ctx context.Context, waitBlocks *big.Int) (*big.Int, error) {
+	if err := p.checkStake(ctx, p.from); err != nil {
+		return nil, err
+	}
+	receipt, err := p.transact(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Token.Stake(opts, waitBlocks)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.stakedSerial(receipt)
+}
+
+//StakeFor stakes unitStaking of the account for the partner, and returns the serial of the stake.
+//The account becomes the payer, which is the only one able to withdraw it.
+func (p *Client) StakeFor(ctx context.Context, partner common.Address, waitBlocks *big.Int) (*big.Int, error) {
+	if err := p.checkStake(ctx, partner); err != nil {
+		return nil, err
+	}
+	receipt, err := p.transact(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Token.StakeDelegated(opts, partner, waitBlocks)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.stakedSerial(receipt)
+}
+
+func (p *Client) checkStake(ctx context.Context, partner common.Address) error {
+	if p.key == nil {
+		return ErrReadOnly
+	}
+	if partner == (common.Address{}) {
+		return ErrZeroAddress
+	}
+	opts := p.callOpts(ctx)
+	allowed, err := p.Token.AllowedPartners(opts, partner)
+	if err != nil {
+		return err
+	}
+	if allowed == false {
+		return fmt.Errorf("%w: %s", ErrNotAllowedPartner, partner.Hex())
+	}
+	unitStaking, err := p.Token.UnitStaking(opts)
+	if err != nil {
+		return err
+	}
+	balance, err := p.Token.BalanceOf(opts, p.from)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(unitStaking) < 0 {
+		return fmt.Errorf("%w: balance %v, unitStaking %v", ErrInsufficientBalance, balance, unitStaking)
+	}
+	return nil
+}
+
+func (p *Client) stakedSerial(receipt *types.Receipt) (*big.Int, error) {
+	for _, l := range receipt.Logs {
+		if e, err := p.Token.ParseStaked(*l); err == nil {
+			return e.Serial, nil
+		}
+	}
+	return nil, fmt.Errorf("wemix: no Staked event in tx %s", receipt.TxHash.Hex())
+}
+
+//Withdraw withdraws the stake of the serial to the account, which must be its payer.
+func (p *Client) Withdraw(ctx context.Context, serial *big.Int) (*types.Receipt, error) {
+	if p.key == nil {
+		return nil, ErrReadOnly
+	}
+	partner, err := p.PartnerBySerial(ctx, serial)
+	if err != nil {
+		return nil, err
+	}
+	if partner.Payer != p.from {
+		return nil, fmt.Errorf("%w: serial %v, payer %s", ErrNotPayer, serial, partner.Payer.Hex())
+	}
+	head, err := p.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	//the transaction is included in the next block at the earliest.
+	if next := new(big.Int).Add(head.Number, common.Big1); partner.IsWithdrawable(next) == false {
+		return nil, fmt.Errorf("%w: serial %v, unlock block %v, next block %v", ErrNotWithdrawable, serial, partner.UnlockBlock(), next)
+	}
+	return p.transact(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Token.Withdraw(opts, serial)
+	})
+}
+
+//Mint mints tokens of the next block unit, once blockToMint is reached by the next block.
+func (p *Client) Mint(ctx context.Context) (*types.Receipt, error) {
+	if p.key == nil {
+		return nil, ErrReadOnly
+	}
+	blockToMint, err := p.Token.BlockToMint(p.callOpts(ctx))
+	if err != nil {
+		return nil, err
+	}
+	head, err := p.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	//the transaction is included in the next block at the earliest.
+	if next := new(big.Int).Add(head.Number, common.Big1); next.Cmp(blockToMint) < 0 {
+		return nil, fmt.Errorf("%w: blockToMint %v, next block %v", ErrNotMintable, blockToMint, next)
+	}
+	return p.transact(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Token.Mint(opts)
+	})
+}
+
+//AllowPartner pre-approves the address to be staked for. Only the owner can do it.
+func (p *Client) AllowPartner(ctx context.Context, partner common.Address) (*types.Receipt, error) {
+	if partner == (common.Address{}) {
+		return nil, ErrZeroAddress
+	}
+	if err := p.checkOwner(ctx); err != nil {
+		return nil, err
+	}
+	return p.transact(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Token.AddAllowedPartner(opts, partner)
+	})
+}
+
+//DisallowPartner cancels the pre-approval of the address. Only the owner can do it.
+func (p *Client) DisallowPartner(ctx context.Context, partner common.Address) (*types.Receipt, error) {
+	if err := p.checkOwner(ctx); err != nil {
+		return nil, err
+	}
+	return p.transact(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Token.RemoveAllowedPartner(opts, partner)
+	})
+}
+
+func (p *Client) checkOwner(ctx context.Context) error {
+	if p.key == nil {
+		return ErrReadOnly
+	}
+	owner, err := p.Token.Owner(p.callOpts(ctx))
+	if err != nil {
+		return err
+	}
+	if owner != p.from {
+		return fmt.Errorf("%w: owner %s", ErrNotOwner, owner.Hex())
+	}
+	return nil
+}
+
+func (p *Client) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := bind.NewKeyedTransactor(p.key)
+	opts.Context = ctx
+	opts.GasPrice = p.GasPrice
+	opts.GasLimit = p.GasLimit
+	return opts
+}
+
+//transact sends a transaction made by send, commits it on a simulated backend, and waits for its receipt.
+//A failed receipt is returned with ErrReverted.
+func (p *Client) transact(ctx context.Context, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
+	if p.key == nil {
+		return nil, ErrReadOnly
+	}
+	opts := p.transactOpts(ctx)
+
+	tx, err := send(opts)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := p.backend.(interface{ Commit() }); ok {
+		c.Commit()
+	}
+	receipt, err := bind.WaitMined(ctx, p.backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("%w: tx %s", ErrReverted, tx.Hash().Hex())
+	}
+	return receipt, nil
+}
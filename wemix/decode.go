@@ -0,0 +1,56 @@
+package wemix
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+//AmountArgs is names of uint256 arguments holding token amounts, which are shown in WEMIX units.
+var AmountArgs = map[string]bool{
+	"amount":          true,
+	"addedValue":      true,
+	"subtractedValue": true,
+	"_value":          true, //change_mintTo*
+	"_unit":           true, //change_unitStaking
+}
+
+//FormatWEMIX formats an amount of 18 decimals in WEMIX, like "1.5 WEMIX".
+func FormatWEMIX(amount *big.Int) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	q, m := new(big.Int).QuoRem(new(big.Int).Abs(amount), unit, new(big.Int))
+	s := sign + q.String()
+	if m.Sign() != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%018s", m.String()), "0")
+	}
+	return s + " WEMIX"
+}
+
+//DecodeCalldata decodes calldata with the ABI like backend.DecodeCalldata, with token amounts in WEMIX.
+func DecodeCalldata(a *abi.ABI, data []byte) (*backend.DecodedCall, error) {
+	r, err := backend.DecodeCalldata(a, data)
+	if err != nil {
+		return nil, err
+	}
+	r.FormatAmounts(AmountArgs, FormatWEMIX)
+	return r, nil
+}
+
+//DecodeTransaction decodes a raw signed transaction with the ABI like backend.DecodeTransaction, with token amounts in WEMIX.
+func DecodeTransaction(a *abi.ABI, raw []byte) (*backend.DecodedTransaction, error) {
+	r, err := backend.DecodeTransaction(a, raw)
+	if err != nil {
+		return nil, err
+	}
+	if r.Call != nil {
+		r.Call.FormatAmounts(AmountArgs, FormatWEMIX)
+	}
+	return r, nil
+}
@@ -0,0 +1,99 @@
+package wemix
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/backend"
+	"github.com/wemade-tree/wemix-token/model"
+)
+
+//RunSchedule runs a schedule of stakes, withdrawals and mints on a contract deployed on the simulated backend,
+//to analyze fairness of mints with ScheduleRun.Fairness. The owner allows and pays every stake,
+//minBlockWaitingWithdrawal is changed to 0 so that a stake can be withdrawn at any step,
+//and each mint is executed as soon as it is mintable.
+func RunSchedule(contract *backend.Contract, steps []model.ScheduleStep) (*model.ScheduleRun, error) {
+	execute := func(method string, args ...interface{}) (*big.Int, error) {
+		r, err := contract.Execute(nil, method, args...)
+		if err != nil {
+			return nil, err
+		}
+		if r.Status != 1 {
+			return nil, fmt.Errorf("%s: %w", method, ErrReverted)
+		}
+		for _, g := range r.Logs {
+			if g.Topics[0] == contract.Abi.Events["Staked"].ID {
+				return g.Topics[3].Big(), nil
+			}
+		}
+		return nil, nil
+	}
+	uint256 := func(method string, args ...interface{}) (*big.Int, error) {
+		v := (*big.Int)(nil)
+		err := contract.Call(&v, method, args...)
+		return v, err
+	}
+	if _, err := execute("change_minBlockWaitingWithdrawal", new(big.Int)); err != nil {
+		return nil, err
+	}
+
+	runner := &model.ScheduleRunner{
+		Stake: func(partner common.Address) (*big.Int, error) {
+			if _, err := execute("addAllowedPartner", partner); err != nil {
+				return nil, err
+			}
+			return execute("stakeDelegated", partner, new(big.Int))
+		},
+		Withdraw: func(serial *big.Int) error {
+			_, err := execute("withdraw", serial)
+			return err
+		},
+		Mint: func() (model.MintRecord, error) {
+			r := model.MintRecord{}
+			number, err := uint256("partnersNumber")
+			if err != nil {
+				return r, err
+			}
+			for i := int64(0); i < number.Int64(); i++ {
+				p := Partner{}
+				if err := contract.Call(&p, "partnerByIndex", big.NewInt(i)); err != nil {
+					return r, err
+				}
+				r.Active = append(r.Active, p.Serial)
+			}
+			next, err := uint256("nextPartnerToMint")
+			if err != nil {
+				return r, err
+			}
+			rate, err := uint256("mintToPartner")
+			if err != nil {
+				return r, err
+			}
+			unit, err := uint256("blockUnitForMint")
+			if err != nil {
+				return r, err
+			}
+			blockToMint, err := uint256("blockToMint")
+			if err != nil {
+				return r, err
+			}
+			//mint is executed in the block after the current one
+			for new(big.Int).Add(contract.Backend.Blockchain().CurrentBlock().Number(), common.Big1).Cmp(blockToMint) < 0 {
+				contract.Backend.Commit()
+			}
+			if _, err := execute("mint"); err != nil {
+				return r, err
+			}
+			r.Block = contract.Backend.Blockchain().CurrentBlock().Number()
+			if len(r.Active) > 0 {
+				if next.Cmp(number) >= 0 {
+					next = new(big.Int)
+				}
+				r.Paid, r.Amount = r.Active[next.Int64()], new(big.Int).Mul(rate, unit)
+			}
+			return r, nil
+		},
+	}
+	return runner.Run(steps)
+}
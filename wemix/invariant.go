@@ -0,0 +1,120 @@
+package wemix
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/backend"
+)
+
+//Invariants returns properties of WemixToken to be registered on a deployed backend.Contract:
+//
+//	contract.Invariants = wemix.Invariants()
+//
+//Each call returns new invariants, since some of them remember the previous block.
+//nextPartnerToMint <= partnersNumber is not one of them: withdraw shrinks allPartners without moving nextPartnerToMint,
+//which the next mint wraps to 0, so nextPartnerToMint can be higher between a withdrawal and a mint.
+func Invariants() []backend.Invariant {
+	holders := map[common.Address]bool{}
+	scanned := uint64(0) //blocks of Transfer events already scanned for holders
+	lastBlockToMint := (*big.Int)(nil)
+
+	return []backend.Invariant{
+		{Name: "sum of balances equals totalSupply", Check: func(c *backend.Contract) error {
+			client, err := invariantClient(c)
+			if err != nil {
+				return err
+			}
+			it, err := client.Token.FilterTransfer(&bind.FilterOpts{Start: scanned}, nil, nil)
+			if err != nil {
+				return err
+			}
+			for it.Next() {
+				holders[it.Event.From], holders[it.Event.To] = true, true
+				scanned = it.Event.Raw.BlockNumber + 1
+			}
+			if err := it.Error(); err != nil {
+				return err
+			}
+			sum := new(big.Int)
+			for holder := range holders {
+				balance, err := client.Token.BalanceOf(nil, holder)
+				if err != nil {
+					return err
+				}
+				sum.Add(sum, balance)
+			}
+			totalSupply, err := client.Token.TotalSupply(nil)
+			if err != nil {
+				return err
+			}
+			if sum.Cmp(totalSupply) != 0 {
+				return fmt.Errorf("sum of balances of %d holders %v, totalSupply %v", len(holders), sum, totalSupply)
+			}
+			return nil
+		}},
+		{Name: "balance of the contract equals the sum of balanceStaking", Check: func(c *backend.Contract) error {
+			client, err := invariantClient(c)
+			if err != nil {
+				return err
+			}
+			partners, err := client.Partners(context.Background())
+			if err != nil {
+				return err
+			}
+			sum := new(big.Int)
+			for _, partner := range partners {
+				sum.Add(sum, partner.BalanceStaking)
+			}
+			balance, err := client.Token.BalanceOf(nil, c.Address)
+			if err != nil {
+				return err
+			}
+			if sum.Cmp(balance) != 0 {
+				return fmt.Errorf("balance of the contract %v, sum of balanceStaking of %d partners %v", balance, len(partners), sum)
+			}
+			return nil
+		}},
+		{Name: "every serial maps to its own index", Check: func(c *backend.Contract) error {
+			client, err := invariantClient(c)
+			if err != nil {
+				return err
+			}
+			partners, err := client.Partners(context.Background())
+			if err != nil {
+				return err
+			}
+			//partnerBySerial reads partnerByIndex(allPartnersIndex[serial]), and serials are unique
+			for i, partner := range partners {
+				if _, err := client.PartnerBySerial(context.Background(), partner.Serial); err != nil {
+					return fmt.Errorf("serial %v at index %d: %w", partner.Serial, i, err)
+				}
+			}
+			return nil
+		}},
+		{Name: "blockToMint never decreases", Check: func(c *backend.Contract) error {
+			client, err := invariantClient(c)
+			if err != nil {
+				return err
+			}
+			blockToMint, err := client.Token.BlockToMint(nil)
+			if err != nil {
+				return err
+			}
+			if lastBlockToMint != nil && blockToMint.Cmp(lastBlockToMint) < 0 {
+				return fmt.Errorf("blockToMint %v, previously %v", blockToMint, lastBlockToMint)
+			}
+			lastBlockToMint = blockToMint
+			return nil
+		}},
+	}
+}
+
+//invariantClient returns a read only client on the backend of the contract.
+//It calls the contract directly, so invariants are not recorded in coverage and gas reports.
+func invariantClient(c *backend.Contract) (*Client, error) {
+	return NewClient(c.Address, c.Backend, nil)
+}
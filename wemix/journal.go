@@ -0,0 +1,128 @@
+package wemix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//Statuses of a withdrawal in a journal.
+const (
+	JournalSent      = "sent" //signed and sent, or about to be sent, and not mined yet
+	JournalWithdrawn = "withdrawn"
+	JournalReverted  = "reverted"
+	JournalDropped   = "dropped" //not sent, or its nonce was used by another transaction
+)
+
+//JournalEntry is a change of the status of a withdrawal.
+type JournalEntry struct {
+	Serial *big.Int      `json:"serial"`
+	Status string        `json:"status"`
+	Tx     common.Hash   `json:"tx"`
+	Nonce  uint64        `json:"nonce"`
+	Raw    hexutil.Bytes `json:"raw,omitempty"` //signed transaction, to send it again after a restart
+	Block  *big.Int      `json:"block"`         //head when sent, or block mined in
+	Error  string        `json:"error,omitempty"`
+	Time   time.Time     `json:"time"`
+}
+
+//Journal is an append-only file of withdrawals of a Withdrawer, an entry per line in JSON.
+//The last entry of a serial is its status, so a restarted agent knows the transactions it already sent.
+type Journal struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]JournalEntry //last entry by serial
+}
+
+//OpenJournal reads the journal at the path, which is created by the first entry if it does not exist.
+//A last line cut by a crash while it was written is truncated away, so the next entry starts on a line of its own.
+func OpenJournal(path string) (*Journal, error) {
+	r := &Journal{path: path, entries: map[string]JournalEntry{}}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	//Append returns only after the whole line is synced, so a line without its newline was never acted on
+	if n := bytes.LastIndexByte(b, '\n') + 1; n < len(b) {
+		if err := os.Truncate(path, int64(n)); err != nil {
+			return nil, err
+		}
+		b = b[:n]
+	}
+	for i, line := range bytes.Split(b, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		e := JournalEntry{}
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, i+1, err)
+		}
+		if e.Serial == nil {
+			return nil, fmt.Errorf("%s:%d: no serial", path, i+1)
+		}
+		r.entries[e.Serial.String()] = e
+	}
+	return r, nil
+}
+
+//Entry returns the last entry of the serial, and whether there is one.
+func (j *Journal) Entry(serial *big.Int) (JournalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	e, ok := j.entries[serial.String()]
+	return e, ok
+}
+
+//Entries returns the last entry of every serial, by serial.
+func (j *Journal) Entries() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ret := make([]JournalEntry, 0, len(j.entries))
+	for _, e := range j.entries {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(a, b int) bool { return ret[a].Serial.Cmp(ret[b].Serial) < 0 })
+	return ret
+}
+
+//Append writes the entry to the file and syncs it, before the entry is the status of its serial.
+func (j *Journal) Append(e JournalEntry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	j.entries[e.Serial.String()] = e
+	return nil
+}
@@ -0,0 +1,297 @@
+package wemix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	//DefaultKeeperInterval is the time between polls of a keeper.
+	DefaultKeeperInterval = 5 * time.Second
+	//DefaultMintGasLimit is the gas limit of mint() sent by a keeper when the client has none.
+	//The gas is not estimated, since the estimation fails when another caller has just minted.
+	DefaultMintGasLimit = 300000
+)
+
+//Keeper calls mint() as soon as it is due, with the key of the client.
+//A backlog is caught up with up to MaxPending calls at a time, sent with consecutive nonces.
+//A call reverted because another caller minted first is counted as lost.
+//A call without receipt whose nonce was used by another transaction is counted as dropped, and the nonce is read again.
+//A call without receipt for PendingTimeout is stuck: it is sent again at every poll, and the keeper is unhealthy until it is mined.
+type Keeper struct {
+	Client         *Client
+	Interval       time.Duration //between polls, DefaultKeeperInterval if 0
+	MaxPending     int           //calls waiting for receipts, 1 if 0
+	Retries        int           //sends retried after an error, with the nonce read again
+	RetryDelay     time.Duration
+	PendingTimeout time.Duration                            //a call without receipt for it is stuck, 10 intervals if 0
+	StaleAfter     time.Duration                            //unhealthy without a successful poll for it, 3 intervals if 0
+	Logf           func(format string, args ...interface{}) //logs sends and errors, nil for none
+
+	mu       sync.Mutex
+	health   KeeperHealth
+	lastPoll time.Time
+	nonce    uint64
+	hasNonce bool
+	pending  []pendingMint
+}
+
+type pendingMint struct {
+	tx   *types.Transaction
+	sent time.Time
+}
+
+//KeeperHealth is the status of a keeper.
+type KeeperHealth struct {
+	Healthy     bool      `json:"healthy"`
+	LastPoll    time.Time `json:"lastPoll"` //last successful poll
+	Block       *big.Int  `json:"block"`
+	BlockToMint *big.Int  `json:"blockToMint"`
+	Backlog     uint64    `json:"backlog"` //calls outstanding in the next block at the last poll
+	Pending     int       `json:"pending"`
+	Stuck       int       `json:"stuck"` //calls pending for the timeout
+	Sent        uint64    `json:"sent"`
+	Resent      uint64    `json:"resent"` //sends again of stuck calls
+	Minted      uint64    `json:"minted"`
+	Lost        uint64    `json:"lost"`
+	Dropped     uint64    `json:"dropped"` //calls whose nonce was used by another transaction
+	Errors      uint64    `json:"errors"`
+	LastError   string    `json:"lastError,omitempty"` //error of the last poll
+}
+
+//NewKeeper returns a keeper sending mint() with the client, which needs a key.
+func NewKeeper(client *Client) (*Keeper, error) {
+	if client.key == nil {
+		return nil, ErrReadOnly
+	}
+	return &Keeper{Client: client}, nil
+}
+
+func (p *Keeper) logf(format string, args ...interface{}) {
+	if p.Logf != nil {
+		p.Logf(format, args...)
+	}
+}
+
+//Health returns the status of the keeper.
+func (p *Keeper) Health() KeeperHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r := p.health
+	stale := p.StaleAfter
+	if stale == 0 {
+		stale = 3 * p.interval()
+	}
+	r.LastPoll = p.lastPoll
+	r.Pending = len(p.pending)
+	for _, m := range p.pending {
+		if p.stuck(m) {
+			r.Stuck++
+		}
+	}
+	r.Healthy = r.LastError == "" && r.Stuck == 0 && p.lastPoll.IsZero() == false && time.Since(p.lastPoll) < stale
+	return r
+}
+
+//ServeHTTP writes the health as JSON, with status 503 when the keeper is unhealthy.
+func (p *Keeper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	health := p.Health()
+	w.Header().Set("Content-Type", "application/json")
+	if health.Healthy == false {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(health)
+}
+
+func (p *Keeper) interval() time.Duration {
+	if p.Interval > 0 {
+		return p.Interval
+	}
+	return DefaultKeeperInterval
+}
+
+func (p *Keeper) stuck(m pendingMint) bool {
+	timeout := p.PendingTimeout
+	if timeout == 0 {
+		timeout = 10 * p.interval()
+	}
+	return time.Since(m.sent) >= timeout
+}
+
+//Run polls until the context is done, and returns nil then. Errors of polls are kept in the health.
+func (p *Keeper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval())
+	defer ticker.Stop()
+	for {
+		if err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			p.logf("keeper: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+//Poll checks receipts of the calls sent, and sends calls outstanding in the next block which are not sent yet.
+func (p *Keeper) Poll(ctx context.Context) error {
+	err := p.poll(ctx)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.health.Errors++
+		p.health.LastError = err.Error()
+		return err
+	}
+	p.health.LastError = ""
+	p.lastPoll = time.Now()
+	return nil
+}
+
+func (p *Keeper) poll(ctx context.Context) error {
+	if err := p.checkPending(ctx); err != nil {
+		return err
+	}
+	head, err := p.Client.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	m, err := p.Client.MintState(ctx, head.Number)
+	if err != nil {
+		return err
+	}
+	backlog, err := m.Backlog(new(big.Int).Add(head.Number, common.Big1))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.health.Block, p.health.BlockToMint, p.health.Backlog = head.Number, backlog.BlockToMint, backlog.Mints
+	pending := len(p.pending)
+	p.mu.Unlock()
+
+	//calls pending are in the backlog until they are mined
+	maxPending := p.MaxPending
+	if maxPending <= 0 {
+		maxPending = 1
+	}
+	for n := pending; uint64(n) < backlog.Mints && n < maxPending; n++ {
+		if err := p.send(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//checkPending counts calls mined or dropped, sends stuck calls again, and keeps the calls not mined.
+func (p *Keeper) checkPending(ctx context.Context) error {
+	p.mu.Lock()
+	pending := p.pending
+	p.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	//the nonce mined is read before the receipts, so a call mined in between has its receipt read
+	nonce, err := p.Client.backend.NonceAt(ctx, p.Client.from, nil)
+	if err != nil {
+		return err
+	}
+
+	left, minted, lost, dropped, resent := []pendingMint{}, uint64(0), uint64(0), uint64(0), uint64(0)
+	for i, m := range pending {
+		tx := m.tx
+		receipt, e := p.Client.backend.TransactionReceipt(ctx, tx.Hash())
+		if errors.Is(e, ethereum.NotFound) || (e == nil && receipt == nil) {
+			if tx.Nonce() < nonce {
+				dropped++
+				p.logf("keeper: mint dropped, nonce %d used by another transaction, tx %s", tx.Nonce(), tx.Hash().Hex())
+				continue
+			}
+			if p.stuck(m) {
+				if e := p.Client.backend.SendTransaction(ctx, tx); e != nil {
+					p.logf("keeper: sending stuck mint again: %v, tx %s", e, tx.Hash().Hex())
+				} else {
+					resent++
+					p.logf("keeper: sent stuck mint again with nonce %d, tx %s", tx.Nonce(), tx.Hash().Hex())
+				}
+			}
+			left = append(left, m)
+			continue
+		}
+		if e != nil {
+			left, err = append(left, pending[i:]...), e
+			break
+		}
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			minted++
+			p.logf("keeper: minted in block %v, tx %s", receipt.BlockNumber, tx.Hash().Hex())
+		} else {
+			lost++
+			p.logf("keeper: mint reverted in block %v, tx %s", receipt.BlockNumber, tx.Hash().Hex())
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = left
+	p.health.Minted += minted
+	p.health.Lost += lost
+	p.health.Dropped += dropped
+	p.health.Resent += resent
+	if dropped > 0 {
+		//the nonces of the calls sent after a dropped one are not used in order anymore
+		p.hasNonce = false
+	}
+	return err
+}
+
+//send sends a call of mint() with the next nonce, retrying with the nonce read again after an error.
+func (p *Keeper) send(ctx context.Context) error {
+	var err error
+	for attempt := 0; attempt <= p.Retries; attempt++ {
+		if attempt > 0 {
+			p.logf("keeper: retrying mint after %v", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.RetryDelay):
+			}
+		}
+		if p.hasNonce == false {
+			if p.nonce, err = p.Client.backend.PendingNonceAt(ctx, p.Client.from); err != nil {
+				continue
+			}
+			p.hasNonce = true
+		}
+		opts := p.Client.transactOpts(ctx)
+		opts.Nonce = new(big.Int).SetUint64(p.nonce)
+		if opts.GasLimit == 0 {
+			opts.GasLimit = DefaultMintGasLimit
+		}
+		tx := (*types.Transaction)(nil)
+		if tx, err = p.Client.Token.Mint(opts); err != nil {
+			p.hasNonce = false
+			continue
+		}
+		p.nonce++
+		p.mu.Lock()
+		p.pending = append(p.pending, pendingMint{tx: tx, sent: time.Now()})
+		p.health.Sent++
+		p.mu.Unlock()
+		p.logf("keeper: sent mint with nonce %d, tx %s", tx.Nonce(), tx.Hash().Hex())
+		return nil
+	}
+	return fmt.Errorf("mint: %v", err)
+}
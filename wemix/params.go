@@ -0,0 +1,119 @@
+package wemix
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//NextBlockUnitForMintSlot is the storage slot of the private nextBlockUnitForMint of WemixToken.
+//The client has no compiler to resolve it from the storage layout, so it is fixed here
+//and checked against the layout of contracts/WemixToken.sol by TestWemixStorageSlotForClient.
+var NextBlockUnitForMintSlot = common.BigToHash(big.NewInt(20))
+
+//Params is the parameters of the contract at a block.
+type Params struct {
+	Block                     *big.Int //block read at
+	UnitStaking               *big.Int
+	MinBlockWaitingWithdrawal *big.Int
+	BlockUnitForMint          *big.Int
+	MintToPartner             *big.Int
+	MintToEcoFund             *big.Int
+	MintToWemix               *big.Int
+	EcoFund                   common.Address
+	Wemix                     common.Address
+	Owner                     common.Address
+	BlockToMint               *big.Int
+	NextPartnerToMint         *big.Int
+	TotalSupply               *big.Int
+	NextBlockUnitForMint      *big.Int //blockUnitForMint applied by the next mint, 0 if no change is pending
+}
+
+//Params returns the parameters of the contract at the current block.
+func (p *Client) Params(ctx context.Context) (*Params, error) {
+	return p.ParamsAt(ctx, nil)
+}
+
+//ParamsAt returns the parameters of the contract, all read at the block or the current block if it is nil.
+//Reading a block which is not the latest needs a node keeping its state, like an archive node.
+func (p *Client) ParamsAt(ctx context.Context, block *big.Int) (*Params, error) {
+	if block == nil {
+		head, err := p.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		block = head.Number
+	}
+	opts := p.callOpts(ctx)
+	opts.BlockNumber = block
+
+	r := &Params{Block: block}
+	var err error
+	for _, read := range []func() error{
+		func() error { r.UnitStaking, err = p.Token.UnitStaking(opts); return err },
+		func() error { r.MinBlockWaitingWithdrawal, err = p.Token.MinBlockWaitingWithdrawal(opts); return err },
+		func() error { r.BlockUnitForMint, err = p.Token.BlockUnitForMint(opts); return err },
+		func() error { r.MintToPartner, err = p.Token.MintToPartner(opts); return err },
+		func() error { r.MintToEcoFund, err = p.Token.MintToEcoFund(opts); return err },
+		func() error { r.MintToWemix, err = p.Token.MintToWemix(opts); return err },
+		func() error { r.EcoFund, err = p.Token.EcoFund(opts); return err },
+		func() error { r.Wemix, err = p.Token.Wemix(opts); return err },
+		func() error { r.Owner, err = p.Token.Owner(opts); return err },
+		func() error { r.BlockToMint, err = p.Token.BlockToMint(opts); return err },
+		func() error { r.NextPartnerToMint, err = p.Token.NextPartnerToMint(opts); return err },
+		func() error { r.TotalSupply, err = p.Token.TotalSupply(opts); return err },
+		func() error {
+			//private, so it is read from the storage
+			value, err := p.backend.StorageAt(ctx, p.Address, NextBlockUnitForMintSlot, block)
+			r.NextBlockUnitForMint = new(big.Int).SetBytes(value)
+			return err
+		},
+	} {
+		if err := read(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+//ParamChange is a field of Params changed between two snapshots.
+type ParamChange struct {
+	Name string
+	Old  interface{}
+	New  interface{}
+}
+
+func (c ParamChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Name, c.Old, c.New)
+}
+
+//DiffParams returns fields changed from old to new in the order of Params. Block is not compared.
+func DiffParams(old, new *Params) []ParamChange {
+	ret := []ParamChange{}
+	o, n := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	for i := 0; i < o.NumField(); i++ {
+		name := o.Type().Field(i).Name
+		if name == "Block" {
+			continue
+		}
+		a, b := o.Field(i).Interface(), n.Field(i).Interface()
+		if equalParam(a, b) == false {
+			ret = append(ret, ParamChange{Name: name, Old: a, New: b})
+		}
+	}
+	return ret
+}
+
+func equalParam(a, b interface{}) bool {
+	if x, ok := a.(*big.Int); ok {
+		y := b.(*big.Int)
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Cmp(y) == 0
+	}
+	return a == b
+}
@@ -0,0 +1,162 @@
+package wemix
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wemade-tree/wemix-token/model"
+)
+
+//Partner is a block partner staked in the contract, as returned by partnerByIndex and partnerBySerial.
+type Partner struct {
+	Serial                 *big.Int
+	Partner                common.Address //receives tokens minted to partners
+	Payer                  common.Address //paid the stake, and is the only one able to withdraw it
+	BlockStaking           *big.Int
+	BlockWaitingWithdrawal *big.Int
+	BalanceStaking         *big.Int
+}
+
+//UnlockBlock returns the first block in which the stake can be withdrawn.
+//The contract does not check the sum by SafeMath, so it wraps around like uint256.
+func (p *Partner) UnlockBlock() *big.Int {
+	unlock := new(big.Int).Add(p.BlockStaking, p.BlockWaitingWithdrawal)
+	return unlock.And(unlock, model.MaxUint256)
+}
+
+//IsWithdrawable returns whether the stake can be withdrawn in the block.
+func (p *Partner) IsWithdrawable(block *big.Int) bool {
+	return block.Cmp(p.UnlockBlock()) >= 0
+}
+
+//IsDelegated returns whether the stake was paid by another account than the partner.
+func (p *Partner) IsDelegated() bool {
+	return p.Partner != p.Payer
+}
+
+func (p *Partner) String() string {
+	return fmt.Sprintf("Partner:%s serial:%v payer:%s balanceStaking:%v blockStaking:%v blockWaitingWithdrawal:%v",
+		p.Partner.Hex(), p.Serial, p.Payer.Hex(), p.BalanceStaking, p.BlockStaking, p.BlockWaitingWithdrawal)
+}
+
+//PartnerBySerial returns the partner of the serial, or ErrPartnerNotFound.
+//The contract maps a withdrawn or never issued serial to index 0 and returns
+//whatever partner is there, so the serial returned is checked against the one requested.
+func (p *Client) PartnerBySerial(ctx context.Context, serial *big.Int) (*Partner, error) {
+	opts := p.callOpts(ctx)
+	partner, err := p.Token.PartnerBySerial(opts, serial)
+	if err != nil {
+		//partnerByIndex reverts when there is no partner at all.
+		if n, e := p.Token.PartnersNumber(opts); e == nil && n.Sign() == 0 {
+			return nil, fmt.Errorf("%w: %v", ErrPartnerNotFound, serial)
+		}
+		return nil, err
+	}
+	if partner.Serial == nil || partner.Serial.Cmp(serial) != 0 {
+		return nil, fmt.Errorf("%w: %v", ErrPartnerNotFound, serial)
+	}
+	r := Partner(partner)
+	return &r, nil
+}
+
+//Partners returns all partners at the current block in the order of the contract.
+func (p *Client) Partners(ctx context.Context) ([]*Partner, error) {
+	it, err := p.PartnerIterator(ctx, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Partner, 0, it.Total())
+	for it.Next() {
+		ret = append(ret, it.Page()...)
+	}
+	return ret, it.Err()
+}
+
+//DefaultPartnerPageSize is the number of partners read per page by PartnerIterator.
+const DefaultPartnerPageSize = 100
+
+//PartnerIterator pages through partners by index, reading all of them at a single block
+//so that withdrawals, which move the last partner into the hole, do not skip or repeat partners.
+type PartnerIterator struct {
+	Block *big.Int //block pinned for all reads
+
+	client   *Client
+	opts     *bind.CallOpts
+	pageSize int64
+	total    int64
+	next     int64
+	page     []*Partner
+	err      error
+}
+
+//PartnerIterator returns an iterator of partners at the block, or the current block if it is nil.
+//Reading a block which is not the latest needs a node keeping its state, like an archive node.
+//pageSize is the number of partners per page, DefaultPartnerPageSize if it is not positive.
+func (p *Client) PartnerIterator(ctx context.Context, block *big.Int, pageSize int) (*PartnerIterator, error) {
+	if block == nil {
+		head, err := p.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		block = head.Number
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPartnerPageSize
+	}
+	opts := p.callOpts(ctx)
+	opts.BlockNumber = block
+
+	n, err := p.Token.PartnersNumber(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PartnerIterator{
+		Block:    block,
+		client:   p,
+		opts:     opts,
+		pageSize: int64(pageSize),
+		total:    n.Int64(),
+	}, nil
+}
+
+//Total returns the number of partners at the block.
+func (it *PartnerIterator) Total() int64 {
+	return it.total
+}
+
+//Next reads the next page, and returns false when all partners are read or an error occurs.
+func (it *PartnerIterator) Next() bool {
+	it.page = nil
+	if it.err != nil || it.next >= it.total {
+		return false
+	}
+	end := it.next + it.pageSize
+	if end > it.total {
+		end = it.total
+	}
+	for i := it.next; i < end; i++ {
+		partner, err := it.client.Token.PartnerByIndex(it.opts, big.NewInt(i))
+		if err != nil {
+			it.err = fmt.Errorf("partner %d at block %v: %w", i, it.Block, err)
+			it.page = nil
+			return false
+		}
+		r := Partner(partner)
+		it.page = append(it.page, &r)
+	}
+	it.next = end
+	return true
+}
+
+//Page returns the partners read by the last Next.
+func (it *PartnerIterator) Page() []*Partner {
+	return it.page
+}
+
+//Err returns the error which stopped the iteration.
+func (it *PartnerIterator) Err() error {
+	return it.err
+}
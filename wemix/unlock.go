@@ -0,0 +1,213 @@
+package wemix
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//DefaultBlockTimeWindow is the number of blocks the block time is averaged over by UnlockReport.
+const DefaultBlockTimeWindow = 1000
+
+//UnlockReport is every stake at a block, with the block and the estimated time it is unlocked.
+type UnlockReport struct {
+	Contract  common.Address `json:"contract"`
+	Block     *big.Int       `json:"block"`
+	Time      time.Time      `json:"time"`      //of the block
+	BlockTime time.Duration  `json:"blockTime"` //estimate the unlock times come from
+	Stakes    []UnlockEntry  `json:"stakes"`
+}
+
+//UnlockEntry is a stake of UnlockReport.
+type UnlockEntry struct {
+	Serial       *big.Int       `json:"serial"`
+	Partner      common.Address `json:"partner"`
+	Payer        common.Address `json:"payer"`
+	Amount       *big.Int       `json:"amount"`
+	UnlockBlock  *big.Int       `json:"unlockBlock"`
+	Remaining    *big.Int       `json:"remaining"`    //blocks until the unlock block, 0 if it is passed
+	UnlockTime   *time.Time     `json:"unlockTime"`   //nil if it is too far to estimate
+	Withdrawable bool           `json:"withdrawable"` //in the next block
+}
+
+//EstimateBlockTime returns the average time of the last window blocks before the block, or the current block if it is nil.
+//It is 1 second, which minBlockWaitingWithdrawal assumes, at the first block.
+func (p *Client) EstimateBlockTime(ctx context.Context, block *big.Int, window int64) (time.Duration, error) {
+	head, err := p.backend.HeaderByNumber(ctx, block)
+	if err != nil {
+		return 0, err
+	}
+	if window > head.Number.Int64() {
+		window = head.Number.Int64()
+	}
+	if window <= 0 {
+		return time.Second, nil
+	}
+	old, err := p.backend.HeaderByNumber(ctx, new(big.Int).Sub(head.Number, big.NewInt(window)))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(head.Time-old.Time) * time.Second / time.Duration(window), nil
+}
+
+//UnlockReport returns every stake at the block, or the current block if it is nil, in the order of the contract.
+//Unlock times are estimated with blockTime, or the average of the last DefaultBlockTimeWindow blocks if it is 0.
+func (p *Client) UnlockReport(ctx context.Context, block *big.Int, blockTime time.Duration) (*UnlockReport, error) {
+	head, err := p.backend.HeaderByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if blockTime == 0 {
+		if blockTime, err = p.EstimateBlockTime(ctx, head.Number, DefaultBlockTimeWindow); err != nil {
+			return nil, err
+		}
+	}
+	r := &UnlockReport{
+		Contract:  p.Address,
+		Block:     head.Number,
+		Time:      time.Unix(int64(head.Time), 0).UTC(),
+		BlockTime: blockTime,
+		Stakes:    []UnlockEntry{},
+	}
+
+	it, err := p.PartnerIterator(ctx, head.Number, 0)
+	if err != nil {
+		return nil, err
+	}
+	next := new(big.Int).Add(head.Number, common.Big1)
+	for it.Next() {
+		for _, partner := range it.Page() {
+			e := UnlockEntry{
+				Serial:       partner.Serial,
+				Partner:      partner.Partner,
+				Payer:        partner.Payer,
+				Amount:       partner.BalanceStaking,
+				UnlockBlock:  partner.UnlockBlock(),
+				Remaining:    new(big.Int),
+				Withdrawable: partner.IsWithdrawable(next),
+			}
+			if e.UnlockBlock.Cmp(head.Number) > 0 {
+				e.Remaining.Sub(e.UnlockBlock, head.Number)
+			}
+			e.UnlockTime = r.EstimateTime(e.Remaining)
+			r.Stakes = append(r.Stakes, e)
+		}
+	}
+	return r, it.Err()
+}
+
+//EstimateTime returns the time of the report plus the blocks at its block time, or nil if it overflows time.Duration.
+func (p *UnlockReport) EstimateTime(blocks *big.Int) *time.Time {
+	if blocks.IsInt64() == false || blocks.Sign() < 0 {
+		return nil
+	}
+	if n := blocks.Int64(); p.BlockTime > 0 && n > math.MaxInt64/int64(p.BlockTime) {
+		return nil
+	}
+	r := p.Time.Add(time.Duration(blocks.Int64()) * p.BlockTime)
+	return &r
+}
+
+//formatTime formats the time in the layout, or returns "" if it is nil.
+func formatTime(t *time.Time, layout string) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(layout)
+}
+
+//WriteTable writes the report as a table, with amounts in WEMIX.
+func (p *UnlockReport) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "block %v at %s, %v per block\n", p.Block, p.Time.Format(time.RFC3339), p.BlockTime); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "serial\tpartner\tpayer\tamount\tunlock block\tremaining\tunlock time\twithdrawable")
+	for _, e := range p.Stakes {
+		fmt.Fprintf(tw, "%v\t%s\t%s\t%s\t%v\t%v\t%s\t%v\n", e.Serial, e.Partner.Hex(), e.Payer.Hex(), FormatWEMIX(e.Amount),
+			e.UnlockBlock, e.Remaining, formatTime(e.UnlockTime, time.RFC3339), e.Withdrawable)
+	}
+	return tw.Flush()
+}
+
+//WriteCSV writes the report as CSV with a header, with amounts in wei.
+func (p *UnlockReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"serial", "partner", "payer", "amount", "unlock_block", "remaining", "unlock_time", "withdrawable"})
+	for _, e := range p.Stakes {
+		cw.Write([]string{e.Serial.String(), e.Partner.Hex(), e.Payer.Hex(), e.Amount.String(),
+			e.UnlockBlock.String(), e.Remaining.String(), formatTime(e.UnlockTime, time.RFC3339), strconv.FormatBool(e.Withdrawable)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//WriteICalendar writes an iCalendar file with an event and a reminder at the estimated unlock time of every stake
+//which is not withdrawable yet and has an unlock time.
+func (p *UnlockReport) WriteICalendar(w io.Writer) error {
+	const stamp = "20060102T150405Z"
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//wemix-token//unlocks//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	for _, e := range p.Stakes {
+		if e.Withdrawable || e.UnlockTime == nil {
+			continue
+		}
+		summary := fmt.Sprintf("WEMIX stake %v unlocks", e.Serial)
+		description := fmt.Sprintf("Serial %v of partner %s, paid by %s, can be withdrawn by the payer from block %v (estimated at %v per block). Amount: %s.",
+			e.Serial, e.Partner.Hex(), e.Payer.Hex(), e.UnlockBlock, p.BlockTime, FormatWEMIX(e.Amount))
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:unlock-%s-%v@wemix-token", strings.ToLower(p.Contract.Hex()), e.Serial),
+			"DTSTAMP:"+p.Time.UTC().Format(stamp),
+			"DTSTART:"+formatTime(e.UnlockTime, stamp),
+			"DURATION:PT15M",
+			"SUMMARY:"+escapeText(summary),
+			"DESCRIPTION:"+escapeText(description),
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"TRIGGER:PT0S",
+			"DESCRIPTION:"+escapeText(summary),
+			"END:VALARM",
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldLine(line)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//escapeText escapes a TEXT value of iCalendar.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+//foldLine ends the line with CRLF, folding it into lines of at most 75 octets as iCalendar requires.
+func foldLine(line string) string {
+	var b strings.Builder
+	for n := 75; len(line) > n; n = 74 { //a folded line starts with a space
+		b.WriteString(line[:n])
+		b.WriteString("\r\n ")
+		line = line[n:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,251 @@
+package wemix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+//WithdrawPolicy returns whether a matured stake paid by a Withdrawer is withdrawn.
+type WithdrawPolicy func(p *Partner) bool
+
+//ReleaseSerials returns a policy withdrawing only the serials.
+func ReleaseSerials(serials ...*big.Int) WithdrawPolicy {
+	in := map[string]bool{}
+	for _, serial := range serials {
+		in[serial.String()] = true
+	}
+	return func(p *Partner) bool {
+		return in[p.Serial.String()]
+	}
+}
+
+//HoldSerials returns a policy withdrawing all serials but the ones given.
+func HoldSerials(serials ...*big.Int) WithdrawPolicy {
+	release := ReleaseSerials(serials...)
+	return func(p *Partner) bool {
+		return release(p) == false
+	}
+}
+
+//Withdrawer withdraws stakes paid by the account of the client once they are unlocked, as the payer is the only one able to.
+//Every withdrawal is written in the journal before it is sent, and a withdrawal sent and not mined is not sent again,
+//so a restarted agent does not withdraw twice. A withdrawal signed but maybe not sent before a crash is sent again as is.
+type Withdrawer struct {
+	Client  *Client
+	Journal *Journal
+	Policy  WithdrawPolicy                           //all stakes are withdrawn if it is nil
+	Logf    func(format string, args ...interface{}) //nil for no logs
+}
+
+//PaidStake is a stake paid by the account of a Withdrawer.
+type PaidStake struct {
+	*Partner
+	Unlock   *big.Int //first block in which it can be withdrawn
+	Eligible bool     //can be withdrawn in the next block
+	Released bool     //by the policy
+	Status   string   //in the journal, empty without an entry
+}
+
+//NewWithdrawer returns an agent withdrawing with the client, which needs a key.
+func NewWithdrawer(client *Client, journal *Journal) (*Withdrawer, error) {
+	if client.key == nil {
+		return nil, ErrReadOnly
+	}
+	if journal == nil {
+		return nil, errors.New("wemix: withdrawer needs a journal")
+	}
+	return &Withdrawer{Client: client, Journal: journal}, nil
+}
+
+func (p *Withdrawer) logf(format string, args ...interface{}) {
+	if p.Logf != nil {
+		p.Logf(format, args...)
+	}
+}
+
+//Stakes returns the stakes paid by the account at the current block, in the order of the contract.
+func (p *Withdrawer) Stakes(ctx context.Context) ([]*PaidStake, error) {
+	it, err := p.Client.PartnerIterator(ctx, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	next := new(big.Int).Add(it.Block, common.Big1)
+	ret := []*PaidStake{}
+	for it.Next() {
+		for _, partner := range it.Page() {
+			if partner.Payer != p.Client.from {
+				continue
+			}
+			s := &PaidStake{
+				Partner:  partner,
+				Unlock:   partner.UnlockBlock(),
+				Eligible: partner.IsWithdrawable(next),
+				Released: p.Policy == nil || p.Policy(partner),
+			}
+			if e, ok := p.Journal.Entry(partner.Serial); ok {
+				s.Status = e.Status
+			}
+			ret = append(ret, s)
+		}
+	}
+	return ret, it.Err()
+}
+
+//Run polls every interval until the context is done, and returns nil then.
+func (p *Withdrawer) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			p.logf("withdrawer: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+//Poll settles withdrawals sent, and sends withdrawals of stakes eligible in the next block and released by the policy,
+//except those sent and not mined yet or already withdrawn.
+func (p *Withdrawer) Poll(ctx context.Context) error {
+	for _, e := range p.Journal.Entries() {
+		if e.Status != JournalSent {
+			continue
+		}
+		if err := p.settle(ctx, e); err != nil {
+			return fmt.Errorf("serial %v: %v", e.Serial, err)
+		}
+	}
+
+	stakes, err := p.Stakes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range stakes {
+		if s.Eligible == false || s.Released == false || s.Status == JournalSent || s.Status == JournalWithdrawn {
+			continue
+		}
+		if err := p.send(ctx, s.Partner); err != nil {
+			return fmt.Errorf("serial %v: %v", s.Serial, err)
+		}
+	}
+	return nil
+}
+
+//settle writes the result of a withdrawal sent, sends it again if the node does not know it,
+//and drops it if its nonce was used by another transaction.
+func (p *Withdrawer) settle(ctx context.Context, e JournalEntry) error {
+	receipt, err := p.receipt(ctx, e.Tx)
+	if err != nil || receipt != nil {
+		return p.settled(e, receipt, err)
+	}
+	pending, err := p.Client.backend.PendingNonceAt(ctx, p.Client.from)
+	if err != nil {
+		return err
+	}
+	if pending == e.Nonce {
+		//signed and journaled, but not sent or lost by the node
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(e.Raw, tx); err != nil {
+			return err
+		}
+		p.logf("withdrawer: sending serial %v again, tx %s", e.Serial, e.Tx.Hex())
+		return p.Client.backend.SendTransaction(ctx, tx)
+	}
+	mined, err := p.Client.backend.NonceAt(ctx, p.Client.from, nil)
+	if err != nil || mined <= e.Nonce {
+		return err //waiting to be mined
+	}
+	//the nonce is mined, which may be this transaction since the receipt was read
+	receipt, err = p.receipt(ctx, e.Tx)
+	if err != nil || receipt != nil {
+		return p.settled(e, receipt, err)
+	}
+	e.Status, e.Error, e.Raw, e.Time = JournalDropped, "nonce used by another transaction", nil, time.Time{}
+	p.logf("withdrawer: serial %v dropped, tx %s", e.Serial, e.Tx.Hex())
+	return p.Journal.Append(e)
+}
+
+//receipt returns the receipt of the transaction, or nil if it is not mined.
+func (p *Withdrawer) receipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := p.Client.backend.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+func (p *Withdrawer) settled(e JournalEntry, receipt *types.Receipt, err error) error {
+	if err != nil {
+		return err
+	}
+	e.Status, e.Block, e.Raw, e.Time = JournalWithdrawn, receipt.BlockNumber, nil, time.Time{}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		e.Status = JournalReverted
+	}
+	p.logf("withdrawer: serial %v %s in block %v, tx %s", e.Serial, e.Status, receipt.BlockNumber, e.Tx.Hex())
+	return p.Journal.Append(e)
+}
+
+//send signs a withdrawal, writes it in the journal and sends it.
+func (p *Withdrawer) send(ctx context.Context, partner *Partner) error {
+	data, err := tokenABI.Pack("withdraw", partner.Serial)
+	if err != nil {
+		return err
+	}
+	backend, from := p.Client.backend, p.Client.from
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	nonce, err := backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return err
+	}
+	gasPrice := p.Client.GasPrice
+	if gasPrice == nil {
+		if gasPrice, err = backend.SuggestGasPrice(ctx); err != nil {
+			return err
+		}
+	}
+	gasLimit := p.Client.GasLimit
+	if gasLimit == 0 {
+		msg := ethereum.CallMsg{From: from, To: &p.Client.Address, Data: data}
+		if gasLimit, err = backend.EstimateGas(ctx, msg); err != nil {
+			return err
+		}
+	}
+	opts := p.Client.transactOpts(ctx)
+	tx, err := opts.Signer(types.HomesteadSigner{}, from, types.NewTransaction(nonce, p.Client.Address, new(big.Int), gasLimit, gasPrice, data))
+	if err != nil {
+		return err
+	}
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+
+	e := JournalEntry{Serial: partner.Serial, Status: JournalSent, Tx: tx.Hash(), Nonce: nonce, Raw: raw, Block: head.Number}
+	if err := p.Journal.Append(e); err != nil {
+		return err
+	}
+	if err := backend.SendTransaction(ctx, tx); err != nil {
+		e.Status, e.Error, e.Raw, e.Time = JournalDropped, err.Error(), nil, time.Time{}
+		if err := p.Journal.Append(e); err != nil {
+			return err
+		}
+		return err
+	}
+	p.logf("withdrawer: sent serial %v unlocked at block %v, tx %s", partner.Serial, partner.UnlockBlock(), tx.Hash().Hex())
+	return nil
+}